@@ -0,0 +1,310 @@
+package isoperiod
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Add returns t advanced by one period, regardless of the repetitions.
+// Negative periods move t backwards.
+//
+// Calendar components are applied with time.AddDate, so they are normalized the same way:
+// adding P1M to January 31 results in March 3 (or March 2 in leap years).
+func (r *Period) Add(t time.Time) time.Time {
+	sign := r.sign()
+
+	return t.AddDate(sign*r.Year, sign*r.Month, sign*(r.Week*7+r.Day)).Add(time.Duration(sign) * r.hms())
+}
+
+// AddInLocation is like Add, but applies the calendar components in loc.
+//
+// Add uses the location of t, which gives unexpected wall clock times if t carries
+// a fixed offset (e.g. parsed from an RFC 3339 string) and the period crosses
+// a daylight saving transition. AddInLocation converts t to loc first, so that
+// P1D always lands on the same wall clock time on the next day.
+// The result is returned in loc.
+func (r *Period) AddInLocation(t time.Time, loc *time.Location) time.Time {
+	return r.Add(t.In(loc))
+}
+
+// Advance moves the time t points to forward by one period.
+//
+//	for t := start; t.Before(end); p.Advance(&t) {
+//		...
+//	}
+func (r *Period) Advance(t *time.Time) {
+	*t = r.Add(*t)
+}
+
+// Sub returns t moved back by one period, regardless of the repetitions.
+// It is the counterpart of Add and uses the same components.
+func (r *Period) Sub(t time.Time) time.Time {
+	sign := -r.sign()
+
+	return t.AddDate(sign*r.Year, sign*r.Month, sign*(r.Week*7+r.Day)).Add(time.Duration(sign) * r.hms())
+}
+
+// Truncate returns the last occurrence of the period counted from anchor that isn't after t,
+// like time.Time.Truncate but with anchor instead of the zero time. This puts t into
+// its schedule slot, e.g. PT15M with an anchor at midnight results in 10:30 for 10:42.
+//
+// Periods with calendar components are stepped through one by one with Add (or Sub
+// for t before anchor), so months keep their irregular length: P1M anchored on January 31
+// has occurrences on March 3 and April 3. Repetitions and the sign of the period are ignored.
+// For periods without length, or ones that don't move forward with every step
+// (see Occurrences), t is returned unchanged.
+func (r *Period) Truncate(t time.Time, anchor time.Time) time.Time {
+	p := r.Clone()
+	p.Negative = false
+
+	if _, ok := p.step(anchor, false); !ok {
+		return t
+	}
+
+	if p.Year == 0 && p.Month == 0 && p.Week == 0 && p.Day == 0 {
+		d := p.hms()
+		offset := t.Sub(anchor)
+
+		n := offset / d
+		if offset < 0 && offset%d != 0 {
+			n--
+		}
+
+		return anchor.Add(n * d)
+	}
+
+	cur := anchor
+	for cur.After(t) {
+		prev, ok := p.step(cur, true)
+		if !ok {
+			return t
+		}
+
+		cur = prev
+	}
+
+	for {
+		next, ok := p.step(cur, false)
+		if !ok {
+			return t
+		}
+
+		if next.After(t) {
+			return cur
+		}
+
+		cur = next
+	}
+}
+
+// Normalize returns a copy of the period with overflowing components carried over.
+//
+// Nanoseconds are carried into seconds, seconds into minutes, minutes into hours
+// and hours into days. Months are carried into years. Days are never carried
+// into weeks or months, since the length of a month is ambiguous.
+//
+// PT90M becomes PT1H30M and P14M becomes P1Y2M. Carrying over saturates at the range of an int.
+func (r *Period) Normalize() *Period {
+	p := &Period{}
+	p.assign(r)
+
+	p.Second = addInt(p.Second, p.Nanosecond/int(time.Second))
+	p.Nanosecond %= int(time.Second)
+	p.Minute = addInt(p.Minute, p.Second/60)
+	p.Second %= 60
+	p.Hour = addInt(p.Hour, p.Minute/60)
+	p.Minute %= 60
+	p.Day = addInt(p.Day, p.Hour/24)
+	p.Hour %= 24
+	p.Year = addInt(p.Year, p.Month/12)
+	p.Month %= 12
+
+	return p
+}
+
+// Between returns the period spanning from a to b.
+//
+// The calendar components are counted first: the result has as many months as can be
+// added to a with time.AddDate without passing b, then as many days as possible,
+// and the remainder in hours, minutes and seconds. Thereby Between(a, b).Add(a) equals b.
+// Partial months are not rounded: January 31 to March 1 results in P29D, not P1M1D.
+//
+// If b is before a, the period from b to a is returned negated.
+func Between(a, b time.Time) *Period {
+	p := &Period{}
+
+	if b.Before(a) {
+		a, b = b, a
+		p.Negative = true
+	}
+	b = b.In(a.Location())
+
+	months := (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+	for months > 0 && a.AddDate(0, months, 0).After(b) {
+		months--
+	}
+
+	days := int(b.Sub(a.AddDate(0, months, 0)) / ApproxDay)
+	for days > 0 && a.AddDate(0, months, days).After(b) {
+		days--
+	}
+	for !a.AddDate(0, months, days+1).After(b) {
+		days++
+	}
+
+	rest := b.Sub(a.AddDate(0, months, days))
+
+	p.Year = months / 12
+	p.Month = months % 12
+	p.Day = days
+	p.Hour = int(rest / time.Hour)
+	p.Minute = int(rest % time.Hour / time.Minute)
+	p.Second = int(rest % time.Minute / time.Second)
+	p.Nanosecond = int(rest % time.Second)
+
+	return p
+}
+
+// Plus returns the component-wise sum of r and o, e.g. P1Y plus P6M is P1Y6M.
+// The repetitions of r are kept, those of o are ignored.
+//
+// Overflowing components are not carried over (PT45M plus PT30M is PT75M),
+// use Normalize for that. Negative periods are subtracted. If all components of the
+// result are zero or negative, the result is a negative period. If the signs differ
+// (P1D plus -PT1H), the components keep their signs, which Validate rejects.
+func (r *Period) Plus(o *Period) *Period {
+	rs, os := r.sign(), o.sign()
+
+	p := &Period{
+		Repetitions: r.Repetitions,
+		Year:        rs*r.Year + os*o.Year,
+		Month:       rs*r.Month + os*o.Month,
+		Week:        rs*r.Week + os*o.Week,
+		Day:         rs*r.Day + os*o.Day,
+		Hour:        rs*r.Hour + os*o.Hour,
+		Minute:      rs*r.Minute + os*o.Minute,
+		Second:      rs*r.Second + os*o.Second,
+		Nanosecond:  rs*r.Nanosecond + os*o.Nanosecond,
+	}
+
+	if p.Year <= 0 && p.Month <= 0 && p.Week <= 0 && p.Day <= 0 &&
+		p.Hour <= 0 && p.Minute <= 0 && p.Second <= 0 && p.Nanosecond <= 0 && !p.IsZero() {
+		p.Negative = true
+		p.Year, p.Month, p.Week, p.Day = -p.Year, -p.Month, -p.Week, -p.Day
+		p.Hour, p.Minute, p.Second, p.Nanosecond = -p.Hour, -p.Minute, -p.Second, -p.Nanosecond
+	}
+
+	return p
+}
+
+// Diff returns the component-wise difference of r and o, e.g. P1Y6M diff P6M is P1Y.
+// It is the counterpart of Plus and keeps the repetitions of r.
+//
+// If o has more of a component than r (P1D diff PT1H), the result contains negative
+// components. Like the mixed signs of ModeExtended, Validate rejects those.
+func (r *Period) Diff(o *Period) *Period {
+	negated := o.Clone()
+	negated.Negative = !negated.Negative
+
+	return r.Plus(negated)
+}
+
+// Scale returns the period with each component multiplied by factor and normalized
+// (see Normalize), e.g. PT30M scaled by 3 is PT1H30M. The repetitions are kept.
+// Components exceeding the range of an int are saturated to math.MaxInt (or math.MinInt).
+//
+// A negative factor flips the sign of the period. Fractional factors aren't supported, since
+// calendar components can't be split: scaling P1M by 0.5 has no exact result.
+func (r *Period) Scale(factor int) *Period {
+	negative := r.Negative
+	if factor < 0 {
+		factor = -factor
+		if factor < 0 {
+			// math.MinInt has no positive counterpart.
+			factor = math.MaxInt
+		}
+		negative = !negative
+	}
+
+	p := &Period{
+		Repetitions: r.Repetitions,
+		Year:        mulInt(r.Year, factor),
+		Month:       mulInt(r.Month, factor),
+		Week:        mulInt(r.Week, factor),
+		Day:         mulInt(r.Day, factor),
+		Hour:        mulInt(r.Hour, factor),
+		Minute:      mulInt(r.Minute, factor),
+		Second:      mulInt(r.Second, factor),
+		Nanosecond:  mulInt(r.Nanosecond, factor),
+		Negative:    negative,
+	}
+
+	return p.Normalize()
+}
+
+// mulInt returns a*b, clamped to the range of an int. b must not be negative.
+func mulInt(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	if c := a * b; c/b == a {
+		return c
+	}
+
+	if a < 0 {
+		return math.MinInt
+	}
+
+	return math.MaxInt
+}
+
+// addInt returns a+b, clamped to the range of an int.
+func addInt(a, b int) int {
+	if b > 0 && a > math.MaxInt-b {
+		return math.MaxInt
+	}
+
+	if b < 0 && a < math.MinInt-b {
+		return math.MinInt
+	}
+
+	return a + b
+}
+
+// Split divides a period of hours, minutes and seconds into n equal sub-periods,
+// e.g. PT1H split into 4 results in four times PT15M. The sub-periods have no repetitions,
+// but keep the sign of the period.
+//
+// Periods with calendar components (years, months, weeks or days) can't be split,
+// since their length is ambiguous. The period has to be evenly divisible without getting
+// more precise: into whole seconds, unless the period has a fraction of a second itself.
+// PT1M1S can't be split into 2 parts.
+func (r *Period) Split(n int) ([]*Period, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("can't split a period into %d parts", n)
+	}
+
+	if r.Year != 0 || r.Month != 0 || r.Week != 0 || r.Day != 0 {
+		return nil, errors.New("can't split periods with calendar components")
+	}
+
+	precision := time.Second
+	if r.Nanosecond != 0 {
+		precision = time.Nanosecond
+	}
+
+	d := r.Duration()
+	if (d/precision)%time.Duration(n) != 0 {
+		return nil, fmt.Errorf("%s isn't evenly divisible into %d parts", r.DurationString(), n)
+	}
+
+	result := make([]*Period, n)
+	for i := range result {
+		result[i] = FromDuration(d / time.Duration(n))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,768 @@
+package isoperiod_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestAdd(t *testing.T) {
+	testTable := []struct {
+		S    string
+		T    string
+		Want string
+	}{
+		{
+			S:    "PT1H30M",
+			T:    "2023-01-01T00:00:00Z",
+			Want: "2023-01-01T01:30:00Z",
+		},
+		{
+			S:    "R/P1M",
+			T:    "2023-01-15T12:00:00Z",
+			Want: "2023-02-15T12:00:00Z",
+		},
+		{
+			S:    "P1M",
+			T:    "2023-01-31T00:00:00Z",
+			Want: "2023-03-03T00:00:00Z",
+		},
+		{
+			S:    "P1M",
+			T:    "2024-01-31T00:00:00Z",
+			Want: "2024-03-02T00:00:00Z",
+		},
+		{
+			S:    "P1Y",
+			T:    "2024-02-29T00:00:00Z",
+			Want: "2025-03-01T00:00:00Z",
+		},
+		{
+			S:    "P2W",
+			T:    "2023-01-01T00:00:00Z",
+			Want: "2023-01-15T00:00:00Z",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		start, _ := time.Parse(time.RFC3339, testCase.T)
+		want, _ := time.Parse(time.RFC3339, testCase.Want)
+
+		if got := p.Add(start); !got.Equal(want) {
+			t.Errorf("%s + %s is %s but should be %s", testCase.T, testCase.S, got, want)
+		}
+	}
+}
+
+func TestSub(t *testing.T) {
+	testTable := []struct {
+		S    string
+		T    string
+		Want string
+	}{
+		{
+			S:    "PT1H30M",
+			T:    "2023-01-01T00:00:00Z",
+			Want: "2022-12-31T22:30:00Z",
+		},
+		{
+			S:    "P1Y2M3DT4H5M6S",
+			T:    "2023-06-15T12:00:00Z",
+			Want: "2022-04-12T07:54:54Z",
+		},
+		{
+			S:    "P1W",
+			T:    "2023-01-01T00:00:00Z",
+			Want: "2022-12-25T00:00:00Z",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		start, _ := time.Parse(time.RFC3339, testCase.T)
+		want, _ := time.Parse(time.RFC3339, testCase.Want)
+
+		if got := p.Sub(start); !got.Equal(want) {
+			t.Errorf("%s - %s is %s but should be %s", testCase.T, testCase.S, got, want)
+		}
+	}
+}
+
+func TestAddSub(t *testing.T) {
+	testTable := []string{
+		"PT1H",
+		"PT1H30M15S",
+		"R5/PT30S",
+		"P3D",
+		"P2W",
+	}
+
+	for _, s := range testTable {
+		p, err := isoperiod.Parse(s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if got := p.Sub(p.Add(now)); !got.Equal(now) {
+			t.Errorf("%s: Sub(Add(now)) is %s but should be %s", s, got, now)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Want string
+	}{
+		{
+			S:    "R2/PT90M",
+			Want: "R2/PT1H30M",
+		},
+		{
+			S:    "R2/PT3600S",
+			Want: "R2/PT1H",
+		},
+		{
+			S:    "R2/PT25H61M61S",
+			Want: "R2/P1DT2H2M1S",
+		},
+		{
+			S:    "R2/P14M45D",
+			Want: "R2/P1Y2M45D",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		n := p.Normalize()
+		if s := n.String(); s != testCase.Want {
+			t.Errorf("normalized %s is %s but should be %s", testCase.S, s, testCase.Want)
+		}
+
+		if p.String() != testCase.S {
+			t.Errorf("Normalize modified the original period to %s", p)
+		}
+	}
+}
+
+func TestNegative(t *testing.T) {
+	testTable := []struct {
+		S        string
+		Want     string
+		Duration time.Duration
+	}{
+		{
+			S:        "R2/-PT2H",
+			Want:     "2022-12-31T22:00:00Z",
+			Duration: -2 * time.Hour,
+		},
+		{
+			S:        "R3/-P1DT30M",
+			Want:     "2022-12-30T23:30:00Z",
+			Duration: -30 * time.Minute,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !p.Negative {
+			t.Errorf("%s should be negative", testCase.S)
+		}
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("period is %s but should be %s", s, testCase.S)
+		}
+
+		want, _ := time.Parse(time.RFC3339, testCase.Want)
+		if got := p.Add(now); !got.Equal(want) {
+			t.Errorf("%s + %s is %s but should be %s", now, testCase.S, got, want)
+		}
+
+		if got := p.Sub(want); !got.Equal(now) {
+			t.Errorf("%s - %s is %s but should be %s", want, testCase.S, got, now)
+		}
+
+		if d := p.Duration(); d != testCase.Duration {
+			t.Errorf("%s: duration is %s but should be %s", testCase.S, d, testCase.Duration)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	testTable := []struct {
+		A    string
+		B    string
+		Want string
+	}{
+		{
+			A:    "2023-01-01T00:00:00Z",
+			B:    "2023-03-15T12:30:00Z",
+			Want: "P2M14DT12H30M",
+		},
+		{
+			A:    "2023-01-31T00:00:00Z",
+			B:    "2023-03-01T00:00:00Z",
+			Want: "P29D",
+		},
+		{
+			A:    "2020-02-29T00:00:00Z",
+			B:    "2021-02-28T00:00:00Z",
+			Want: "P11M30D",
+		},
+		{
+			A:    "2020-01-15T10:00:00Z",
+			B:    "2023-04-15T10:00:01.5Z",
+			Want: "P3Y3MT1.5S",
+		},
+		{
+			A:    "2023-01-01T02:00:00Z",
+			B:    "2023-01-01T00:00:00Z",
+			Want: "-PT2H",
+		},
+		{
+			A:    "2023-01-01T00:00:00Z",
+			B:    "2023-01-01T00:00:00Z",
+			Want: "PT0S",
+		},
+	}
+
+	for _, testCase := range testTable {
+		a, _ := time.Parse(time.RFC3339, testCase.A)
+		b, _ := time.Parse(time.RFC3339, testCase.B)
+
+		p := isoperiod.Between(a, b)
+		if s := p.String(); s != testCase.Want {
+			t.Errorf("between %s and %s is %s but should be %s", testCase.A, testCase.B, s, testCase.Want)
+		}
+
+		if !p.Negative && !p.Add(a).Equal(b) {
+			t.Errorf("%s + %s is %s but should be %s", testCase.A, p, p.Add(a), b)
+		}
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	p, err := isoperiod.Parse("P1M2DT3H")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor := now
+	want := now
+	for i := 0; i < 3; i++ {
+		p.Advance(&cursor)
+		want = p.Add(want)
+	}
+
+	if !cursor.Equal(want) {
+		t.Errorf("cursor is %s but should be %s", cursor, want)
+	}
+}
+
+func TestWeeks(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	p, err := isoperiod.Parse("R2/P2W")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Daylight saving time starts on March 12, 2023 in New York.
+	start := time.Date(2023, time.March, 5, 9, 0, 0, 0, loc)
+	want := time.Date(2023, time.March, 19, 9, 0, 0, 0, loc)
+
+	if got := p.Add(start); !got.Equal(want) {
+		t.Errorf("add is %s but should be %s", got, want)
+	}
+
+	if got := p.Next(start); !got.Equal(want) {
+		t.Errorf("next is %s but should be %s", got, want)
+	}
+
+	if got := p.Sub(want); !got.Equal(start) {
+		t.Errorf("sub is %s but should be %s", got, start)
+	}
+
+	if got := isoperiod.New(now, isoperiod.WithWeeks(2)).Add(start); !got.Equal(want) {
+		t.Errorf("add is %s but should be %s", got, want)
+	}
+
+	if d := p.Duration(); d != 0 {
+		t.Errorf("duration is %s but should be 0s", d)
+	}
+
+	if d, want := p.ApproxDuration(), 2*isoperiod.ApproxWeek; d != want {
+		t.Errorf("approximate duration is %s but should be %s", d, want)
+	}
+}
+
+func TestAddInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	testTable := []struct {
+		S    string
+		T    string
+		Want time.Time
+	}{
+		{
+			S:    "P1D",
+			T:    "2023-03-11T10:00:00-05:00",
+			Want: time.Date(2023, time.March, 12, 10, 0, 0, 0, loc),
+		},
+		{
+			S:    "P1DT2H",
+			T:    "2023-03-11T10:00:00-05:00",
+			Want: time.Date(2023, time.March, 12, 12, 0, 0, 0, loc),
+		},
+		{
+			S:    "-P1D",
+			T:    "2023-03-12T10:00:00-04:00",
+			Want: time.Date(2023, time.March, 11, 10, 0, 0, 0, loc),
+		},
+		{
+			S:    "PT24H",
+			T:    "2023-03-11T10:00:00-05:00",
+			Want: time.Date(2023, time.March, 12, 11, 0, 0, 0, loc),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		start, _ := time.Parse(time.RFC3339, testCase.T)
+
+		got := p.AddInLocation(start, loc)
+		if !got.Equal(testCase.Want) {
+			t.Errorf("%s + %s is %s but should be %s", testCase.T, testCase.S, got, testCase.Want)
+		}
+
+		if got.Location() != loc {
+			t.Errorf("location is %s but should be %s", got.Location(), loc)
+		}
+	}
+
+	// With a fixed offset, Add keeps the offset and misses the wall clock time.
+	p, _ := isoperiod.Parse("P1D")
+	start, _ := time.Parse(time.RFC3339, "2023-03-11T10:00:00-05:00")
+	if got, want := p.Add(start), time.Date(2023, time.March, 12, 11, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("add is %s but should be %s", got, want)
+	}
+}
+
+func TestPlus(t *testing.T) {
+	testTable := []struct {
+		A    string
+		B    string
+		Want string
+	}{
+		{
+			A:    "P1Y",
+			B:    "P6M",
+			Want: "P1Y6M",
+		},
+		{
+			A:    "R5/P1DT12H",
+			B:    "R2/PT30M",
+			Want: "R5/P1DT12H30M",
+		},
+		{
+			A:    "PT45M",
+			B:    "PT30M",
+			Want: "PT75M",
+		},
+		{
+			A:    "P1DT2H",
+			B:    "-PT2H",
+			Want: "P1D",
+		},
+		{
+			A:    "PT1H",
+			B:    "-PT3H",
+			Want: "-PT2H",
+		},
+		{
+			A:    "-P1D",
+			B:    "-P2D",
+			Want: "-P3D",
+		},
+	}
+
+	for _, testCase := range testTable {
+		a, err := isoperiod.Parse(testCase.A)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		b, err := isoperiod.Parse(testCase.B)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := a.Plus(b).String(); s != testCase.Want {
+			t.Errorf("%s + %s is %s but should be %s", testCase.A, testCase.B, s, testCase.Want)
+		}
+	}
+
+	a, _ := isoperiod.Parse("PT45M")
+	b, _ := isoperiod.Parse("PT30M")
+	if s, want := a.Plus(b).Normalize().String(), "PT1H15M"; s != want {
+		t.Errorf("normalized sum is %s but should be %s", s, want)
+	}
+
+	a, _ = isoperiod.Parse("P1D")
+	b, _ = isoperiod.Parse("-PT1H")
+	if err := a.Plus(b).Validate(); err == nil {
+		t.Error("error is nil but should be set for mixed signs")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	testTable := []struct {
+		A    string
+		B    string
+		Want string
+	}{
+		{
+			A:    "P1Y6M",
+			B:    "P6M",
+			Want: "P1Y",
+		},
+		{
+			A:    "R5/PT90M",
+			B:    "R2/PT30M",
+			Want: "R5/PT60M",
+		},
+		{
+			A:    "PT1H",
+			B:    "PT3H",
+			Want: "-PT2H",
+		},
+		{
+			A:    "P1D",
+			B:    "-P2D",
+			Want: "P3D",
+		},
+		{
+			A:    "P1D",
+			B:    "PT1H",
+			Want: "P1DT-1H",
+		},
+		{
+			A:    "P1M",
+			B:    "P1M",
+			Want: "PT0S",
+		},
+	}
+
+	for _, testCase := range testTable {
+		a, err := isoperiod.Parse(testCase.A)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		b, err := isoperiod.Parse(testCase.B)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := a.Diff(b).String(); s != testCase.Want {
+			t.Errorf("%s diff %s is %s but should be %s", testCase.A, testCase.B, s, testCase.Want)
+		}
+	}
+
+	a, _ := isoperiod.Parse("P1D")
+	b, _ := isoperiod.Parse("PT1H")
+	if err := a.Diff(b).Validate(); err == nil {
+		t.Error("error is nil but should be set for negative components")
+	}
+
+	if s, want := b.String(), "PT1H"; s != want {
+		t.Errorf("operand is %s but should be unchanged %s", s, want)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	anchor, _ := time.Parse(time.RFC3339, "2023-01-01T00:00:00Z")
+
+	testTable := []struct {
+		S    string
+		T    string
+		Want string
+	}{
+		{
+			S:    "PT15M",
+			T:    "2023-01-01T10:42:10Z",
+			Want: "2023-01-01T10:30:00Z",
+		},
+		{
+			S:    "PT15M",
+			T:    "2023-01-01T10:45:00Z",
+			Want: "2023-01-01T10:45:00Z",
+		},
+		{
+			S:    "PT15M",
+			T:    "2022-12-31T23:50:00Z",
+			Want: "2022-12-31T23:45:00Z",
+		},
+		{
+			S:    "PT15M",
+			T:    "2022-12-31T23:45:00Z",
+			Want: "2022-12-31T23:45:00Z",
+		},
+		{
+			S:    "R/-PT15M",
+			T:    "2023-01-01T00:14:59Z",
+			Want: "2023-01-01T00:00:00Z",
+		},
+		{
+			S:    "P1M",
+			T:    "2023-03-20T08:00:00Z",
+			Want: "2023-03-01T00:00:00Z",
+		},
+		{
+			S:    "P1W",
+			T:    "2022-12-30T08:00:00Z",
+			Want: "2022-12-25T00:00:00Z",
+		},
+		{
+			S:    "PT0S",
+			T:    "2023-01-01T10:42:10Z",
+			Want: "2023-01-01T10:42:10Z",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		ts, _ := time.Parse(time.RFC3339, testCase.T)
+		want, _ := time.Parse(time.RFC3339, testCase.Want)
+
+		if got := p.Truncate(ts, anchor); !got.Equal(want) {
+			t.Errorf("%s: %s truncated is %s but should be %s", testCase.S, testCase.T, got, want)
+		}
+	}
+
+	// Months are stepped through one by one.
+	p, _ := isoperiod.Parse("P1M")
+	jan31, _ := time.Parse(time.RFC3339, "2023-01-31T00:00:00Z")
+	ts, _ := time.Parse(time.RFC3339, "2023-04-10T00:00:00Z")
+	want, _ := time.Parse(time.RFC3339, "2023-04-03T00:00:00Z")
+	if got := p.Truncate(ts, jan31); !got.Equal(want) {
+		t.Errorf("truncated is %s but should be %s", got, want)
+	}
+
+	// Periods that step back have no slots.
+	p, _ = isoperiod.ParseWithMode("P1M-30D", isoperiod.ModeExtended)
+	for _, s := range []string{"2023-12-31T00:00:00Z", "2022-06-01T00:00:00Z"} {
+		ts, _ := time.Parse(time.RFC3339, s)
+		if got := p.Truncate(ts, jan31); !got.Equal(ts) {
+			t.Errorf("%s: truncated is %s but should be unchanged", s, got)
+		}
+	}
+}
+
+func TestScale(t *testing.T) {
+	testTable := []struct {
+		S      string
+		Factor int
+		Want   string
+	}{
+		{
+			S:      "PT30M",
+			Factor: 3,
+			Want:   "PT1H30M",
+		},
+		{
+			S:      "PT15M",
+			Factor: 2,
+			Want:   "PT30M",
+		},
+		{
+			S:      "R5/P1Y6MT0.5S",
+			Factor: 2,
+			Want:   "R5/P3YT1S",
+		},
+		{
+			S:      "PT16H",
+			Factor: 2,
+			Want:   "P1DT8H",
+		},
+		{
+			S:      "P1D",
+			Factor: -2,
+			Want:   "-P2D",
+		},
+		{
+			S:      "-PT1H",
+			Factor: -1,
+			Want:   "PT1H",
+		},
+		{
+			S:      "P1W",
+			Factor: 0,
+			Want:   "PT0S",
+		},
+		{
+			S:      "P2Y",
+			Factor: math.MaxInt,
+			Want:   "P" + strconv.Itoa(math.MaxInt) + "Y",
+		},
+		{
+			S:      "P2Y",
+			Factor: math.MinInt,
+			Want:   "-P" + strconv.Itoa(math.MaxInt) + "Y",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := p.Scale(testCase.Factor).String(); s != testCase.Want {
+			t.Errorf("%s * %d is %s but should be %s", testCase.S, testCase.Factor, s, testCase.Want)
+		}
+	}
+
+	// Carrying over saturates, too.
+	p := &isoperiod.Period{Year: math.MaxInt, Month: 24}
+	if scaled := p.Scale(1); scaled.Year != math.MaxInt || scaled.Month != 0 {
+		t.Errorf("scaled is %d years and %d months but should be %d and 0", scaled.Year, scaled.Month, math.MaxInt)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	testTable := []struct {
+		S    string
+		N    int
+		Want string
+		Err  error
+	}{
+		{
+			S:    "PT1H",
+			N:    4,
+			Want: "PT15M",
+		},
+		{
+			S:    "R/PT1H30M",
+			N:    4,
+			Want: "PT22M30S",
+		},
+		{
+			S:    "-PT1.5S",
+			N:    4,
+			Want: "-PT0.375S",
+		},
+		{
+			S:    "PT10S",
+			N:    1,
+			Want: "PT10S",
+		},
+		{
+			S:   "PT1M1S",
+			N:   2,
+			Err: errors.New("PT1M1S isn't evenly divisible into 2 parts"),
+		},
+		{
+			S:   "P1DT1H",
+			N:   5,
+			Err: errors.New("can't split periods with calendar components"),
+		},
+		{
+			S:   "PT1H",
+			N:   0,
+			Err: errors.New("can't split a period into 0 parts"),
+		},
+		{
+			S:    "PT-1H",
+			N:    4,
+			Want: "-PT15M",
+		},
+		{
+			S:    "PT1H-30M",
+			N:    2,
+			Want: "PT15M",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.ParseWithMode(testCase.S, isoperiod.ModeExtended)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		parts, err := p.Split(testCase.N)
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		if len(parts) != testCase.N {
+			t.Errorf("%s: split into %d parts but should be %d", testCase.S, len(parts), testCase.N)
+		}
+
+		var sum time.Duration
+		for _, part := range parts {
+			if s := part.String(); s != testCase.Want {
+				t.Errorf("%s: part is %s but should be %s", testCase.S, s, testCase.Want)
+			}
+
+			sum += part.Duration()
+		}
+
+		if sum != p.Duration() {
+			t.Errorf("%s: parts sum up to %s but should be %s", testCase.S, sum, p.Duration())
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package isoperiod
+
+// A Builder constructs a Period step by step.
+//
+//	p := isoperiod.NewBuilder().Years(1).Months(6).Seconds(30).Build()
+type Builder struct {
+	period Period
+}
+
+// NewBuilder returns an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Repetitions sets the amount of repetitions.
+func (b *Builder) Repetitions(n int) *Builder {
+	b.period.Repetitions = n
+	return b
+}
+
+// Years sets the years.
+func (b *Builder) Years(n int) *Builder {
+	b.period.Year = n
+	return b
+}
+
+// Months sets the months.
+func (b *Builder) Months(n int) *Builder {
+	b.period.Month = n
+	return b
+}
+
+// Weeks sets the weeks.
+func (b *Builder) Weeks(n int) *Builder {
+	b.period.Week = n
+	return b
+}
+
+// Days sets the days.
+func (b *Builder) Days(n int) *Builder {
+	b.period.Day = n
+	return b
+}
+
+// Hours sets the hours.
+func (b *Builder) Hours(n int) *Builder {
+	b.period.Hour = n
+	return b
+}
+
+// Minutes sets the minutes.
+func (b *Builder) Minutes(n int) *Builder {
+	b.period.Minute = n
+	return b
+}
+
+// Seconds sets the seconds.
+func (b *Builder) Seconds(n int) *Builder {
+	b.period.Second = n
+	return b
+}
+
+// Nanoseconds sets the fraction of a second in nanoseconds.
+func (b *Builder) Nanoseconds(n int) *Builder {
+	b.period.Nanosecond = n
+	return b
+}
+
+// Build returns a new Period with the collected components.
+// The Builder can be reused afterwards.
+func (b *Builder) Build() *Period {
+	p := &Period{}
+	p.assign(&b.period)
+
+	return p
+}
@@ -0,0 +1,44 @@
+package isoperiod_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestBuilder(t *testing.T) {
+	testTable := []struct {
+		Builder  *isoperiod.Builder
+		S        string
+		Duration time.Duration
+	}{
+		{
+			Builder:  isoperiod.NewBuilder().Repetitions(3).Years(1).Months(6).Seconds(30),
+			S:        "R3/P1Y6MT30S",
+			Duration: 30 * time.Second,
+		},
+		{
+			Builder:  isoperiod.NewBuilder().Repetitions(1).Hours(2).Minutes(15),
+			S:        "R1/PT2H15M",
+			Duration: 2*time.Hour + 15*time.Minute,
+		},
+		{
+			Builder:  isoperiod.NewBuilder().Repetitions(2).Weeks(2),
+			S:        "R2/P2W",
+			Duration: 0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p := testCase.Builder.Build()
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("period is %s but should be %s", s, testCase.S)
+		}
+
+		if d := p.Duration(); d != testCase.Duration {
+			t.Errorf("%s: duration is %s but should be %s", p, d, testCase.Duration)
+		}
+	}
+}
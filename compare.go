@@ -0,0 +1,125 @@
+package isoperiod
+
+// Equal reports whether r and o have the same repetitions and components.
+//
+// The comparison is structural: PT60S and PT1M are not equal, even though
+// they describe the same length.
+func (r *Period) Equal(o *Period) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+
+	return r.Repetitions == o.Repetitions &&
+		r.Year == o.Year &&
+		r.Month == o.Month &&
+		r.Week == o.Week &&
+		r.Day == o.Day &&
+		r.Hour == o.Hour &&
+		r.Minute == o.Minute &&
+		r.Second == o.Second &&
+		r.Nanosecond == o.Nanosecond &&
+		r.Negative == o.Negative &&
+		r.Duration() == o.Duration()
+}
+
+// EqualNormalized reports whether r and o are equal after normalizing both.
+// Unlike Equal, PT60S and PT1M are considered equal.
+func (r *Period) EqualNormalized(o *Period) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+
+	return r.Normalize().Equal(o.Normalize())
+}
+
+// IsZero reports whether all components of the period are zero.
+// The repetitions are ignored.
+func (r *Period) IsZero() bool {
+	return r.Year == 0 &&
+		r.Month == 0 &&
+		r.Week == 0 &&
+		r.Day == 0 &&
+		r.Hour == 0 &&
+		r.Minute == 0 &&
+		r.Second == 0 &&
+		r.Nanosecond == 0
+}
+
+// IsExact reports whether the period has a fixed length, i.e. has no years or months.
+// Weeks and days count as exact, taking a day as 24 hours, as ApproxDuration does.
+func (r *Period) IsExact() bool {
+	return r.Year == 0 && r.Month == 0
+}
+
+// Compare returns -1 if r is shorter than o, 1 if r is longer than o and 0 if both are equal.
+//
+// The lengths are compared by ApproxDuration, so a month counts as 30 days and a year
+// as 365 days. Periods of the same approximate length are ordered by their components
+// in field order (years first, nanoseconds last), then by their repetitions:
+// P30D sorts before P1M, and PT60S before PT1M.
+func (r *Period) Compare(o *Period) int {
+	if c := compareInt(int64(r.ApproxDuration()), int64(o.ApproxDuration())); c != 0 {
+		return c
+	}
+
+	rs, os := r.sign(), o.sign()
+	fields := [][2]int{
+		{rs * r.Year, os * o.Year},
+		{rs * r.Month, os * o.Month},
+		{rs * r.Week, os * o.Week},
+		{rs * r.Day, os * o.Day},
+		{rs * r.Hour, os * o.Hour},
+		{rs * r.Minute, os * o.Minute},
+		{rs * r.Second, os * o.Second},
+		{rs * r.Nanosecond, os * o.Nanosecond},
+		{r.Repetitions, o.Repetitions},
+	}
+
+	for _, f := range fields {
+		if c := compareInt(int64(f[0]), int64(f[1])); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// Max returns the longest of the periods as ordered by Compare, or nil if there are none.
+// If several periods compare as equal, the first one is returned.
+func Max(periods ...*Period) *Period {
+	return pick(periods, 1)
+}
+
+// Min returns the shortest of the periods as ordered by Compare, or nil if there are none.
+// If several periods compare as equal, the first one is returned.
+func Min(periods ...*Period) *Period {
+	return pick(periods, -1)
+}
+
+// pick returns the period that compares to all others as want, preferring earlier ones on ties.
+func pick(periods []*Period, want int) *Period {
+	if len(periods) == 0 {
+		return nil
+	}
+
+	result := periods[0]
+	for _, p := range periods[1:] {
+		if p.Compare(result) == want {
+			result = p
+		}
+	}
+
+	return result
+}
+
+// compareInt returns -1, 0 or 1 as a is less than, equal to or greater than b.
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,345 @@
+package isoperiod_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestEqual(t *testing.T) {
+	testTable := []struct {
+		A     string
+		B     string
+		Equal bool
+	}{
+		{
+			A:     "P1Y6M",
+			B:     "P1Y6M",
+			Equal: true,
+		},
+		{
+			A:     "R5/PT30S",
+			B:     "R5/PT30S",
+			Equal: true,
+		},
+		{
+			A:     "R5/PT30S",
+			B:     "R4/PT30S",
+			Equal: false,
+		},
+		{
+			A:     "PT60S",
+			B:     "PT1M",
+			Equal: false,
+		},
+		{
+			A:     "P1W",
+			B:     "P7D",
+			Equal: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		a, err := isoperiod.Parse(testCase.A)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		b, err := isoperiod.Parse(testCase.B)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if a.Equal(b) != testCase.Equal {
+			t.Errorf("%s == %s should be %t", testCase.A, testCase.B, testCase.Equal)
+		}
+	}
+
+	var p *isoperiod.Period
+	if !p.Equal(nil) {
+		t.Error("nil periods should be equal")
+	}
+
+	if p.Equal(isoperiod.New(now)) {
+		t.Error("nil and zero period shouldn't be equal")
+	}
+}
+
+func TestEqualNormalized(t *testing.T) {
+	testTable := []struct {
+		A     string
+		B     string
+		Equal bool
+	}{
+		{
+			A:     "PT60S",
+			B:     "PT1M",
+			Equal: true,
+		},
+		{
+			A:     "PT90M",
+			B:     "PT1H30M",
+			Equal: true,
+		},
+		{
+			A:     "P12M",
+			B:     "P1Y",
+			Equal: true,
+		},
+		{
+			A:     "P30D",
+			B:     "P1M",
+			Equal: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		a, err := isoperiod.Parse(testCase.A)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		b, err := isoperiod.Parse(testCase.B)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if a.EqualNormalized(b) != testCase.Equal {
+			t.Errorf("%s == %s should be %t", testCase.A, testCase.B, testCase.Equal)
+		}
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Zero bool
+	}{
+		{
+			S:    "PT0S",
+			Zero: true,
+		},
+		{
+			S:    "R5/P0D",
+			Zero: true,
+		},
+		{
+			S:    "PT1S",
+			Zero: false,
+		},
+		{
+			S:    "P1W",
+			Zero: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if p.IsZero() != testCase.Zero {
+			t.Errorf("%s: IsZero should be %t", testCase.S, testCase.Zero)
+		}
+	}
+
+	var p isoperiod.Period
+	if !p.IsZero() {
+		t.Error("zero value should be zero")
+	}
+}
+
+func TestIsExact(t *testing.T) {
+	testTable := []struct {
+		S     string
+		Exact bool
+	}{
+		{
+			S:     "PT1H",
+			Exact: true,
+		},
+		{
+			S:     "P1D",
+			Exact: true,
+		},
+		{
+			S:     "R/P2W",
+			Exact: true,
+		},
+		{
+			S:     "P1M",
+			Exact: false,
+		},
+		{
+			S:     "P1Y",
+			Exact: false,
+		},
+		{
+			S:     "P1YT1S",
+			Exact: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if p.IsExact() != testCase.Exact {
+			t.Errorf("%s: IsExact should be %t", testCase.S, testCase.Exact)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	testTable := []struct {
+		A       string
+		B       string
+		Compare int
+	}{
+		{
+			A:       "PT1H",
+			B:       "P1D",
+			Compare: -1,
+		},
+		{
+			A:       "P1M",
+			B:       "P1D",
+			Compare: 1,
+		},
+		{
+			A:       "P1D",
+			B:       "R5/P1D",
+			Compare: -1,
+		},
+		{
+			A:       "-P1D",
+			B:       "PT1H",
+			Compare: -1,
+		},
+		{
+			A:       "P30D",
+			B:       "P1M",
+			Compare: -1,
+		},
+		{
+			A:       "PT1M",
+			B:       "PT60S",
+			Compare: 1,
+		},
+		{
+			A:       "P1W",
+			B:       "P1W",
+			Compare: 0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		a, err := isoperiod.Parse(testCase.A)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		b, err := isoperiod.Parse(testCase.B)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if c := a.Compare(b); c != testCase.Compare {
+			t.Errorf("%s compared to %s is %d but should be %d", testCase.A, testCase.B, c, testCase.Compare)
+		}
+
+		if c := b.Compare(a); c != -testCase.Compare {
+			t.Errorf("%s compared to %s is %d but should be %d", testCase.B, testCase.A, c, -testCase.Compare)
+		}
+	}
+
+	periods, err := isoperiod.ParseAll("P1D PT1H P1M")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].Compare(periods[j]) < 0
+	})
+
+	sorted := make([]string, len(periods))
+	for i, p := range periods {
+		sorted[i] = p.String()
+	}
+
+	if s, want := strings.Join(sorted, " "), "PT1H P1D P1M"; s != want {
+		t.Errorf("sorted is %s but should be %s", s, want)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	testTable := []struct {
+		S   string
+		Max string
+		Min string
+	}{
+		{
+			S:   "P1D PT1H P1M PT30M",
+			Max: "P1M",
+			Min: "PT30M",
+		},
+		{
+			S:   "P1Y P12M P365D",
+			Max: "P1Y",
+			Min: "P12M",
+		},
+		{
+			S:   "-PT1H PT0S",
+			Max: "PT0S",
+			Min: "-PT1H",
+		},
+		{
+			S:   "R5/PT1M",
+			Max: "R5/PT1M",
+			Min: "R5/PT1M",
+		},
+	}
+
+	for _, testCase := range testTable {
+		periods, err := isoperiod.ParseAll(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := isoperiod.Max(periods...).String(); s != testCase.Max {
+			t.Errorf("max of %s is %s but should be %s", testCase.S, s, testCase.Max)
+		}
+
+		if s := isoperiod.Min(periods...).String(); s != testCase.Min {
+			t.Errorf("min of %s is %s but should be %s", testCase.S, s, testCase.Min)
+		}
+	}
+
+	if p := isoperiod.Max(); p != nil {
+		t.Errorf("max of nothing is %s but should be nil", p)
+	}
+
+	if p := isoperiod.Min(); p != nil {
+		t.Errorf("min of nothing is %s but should be nil", p)
+	}
+
+	a, _ := isoperiod.Parse("PT1H")
+	b, _ := isoperiod.Parse("PT1H")
+	if isoperiod.Max(a, b) != a || isoperiod.Min(a, b) != a {
+		t.Error("ties should return the first period")
+	}
+}
@@ -0,0 +1,95 @@
+package isoperiod
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ToCron converts the period to a standard 5-field cron expression.
+//
+// Only periods consisting of a single unit that evenly divides the next larger unit
+// can be represented, e.g. PT15M (*/15 * * * *), PT1H (0 * * * *) or P1D (0 0 * * *).
+// Sub-minute precision, mixed units, negative periods or components and finite repetitions are rejected.
+func (r *Period) ToCron() (string, error) {
+	if r.Repetitions > 0 {
+		return "", errors.New("cron can't limit the amount of repetitions")
+	}
+
+	if r.Negative {
+		return "", errors.New("cron can't express negative periods")
+	}
+
+	// Signed components (ModeExtended) would pass the divisibility checks below, e.g. 60%-15 == 0.
+	for _, n := range []int{r.Year, r.Month, r.Week, r.Day, r.Hour, r.Minute, r.Second, r.Nanosecond} {
+		if n < 0 {
+			return "", errors.New("cron can't express negative components")
+		}
+	}
+
+	if r.Second != 0 || r.Nanosecond != 0 {
+		return "", errors.New("cron doesn't support sub-minute precision")
+	}
+
+	units := 0
+	for _, n := range []int{r.Year, r.Month, r.Week, r.Day, r.Hour, r.Minute} {
+		if n != 0 {
+			units++
+		}
+	}
+
+	if units == 0 {
+		return "", errors.New("cron can't express an empty period")
+	}
+
+	if units > 1 {
+		return "", errors.New("cron can't express periods with mixed units")
+	}
+
+	step := func(n int) string {
+		if n == 1 {
+			return "*"
+		}
+
+		return "*/" + strconv.Itoa(n)
+	}
+
+	switch {
+	case r.Minute != 0:
+		if 60%r.Minute != 0 {
+			return "", fmt.Errorf("cron can't express every %d minutes, it doesn't divide an hour", r.Minute)
+		}
+
+		return step(r.Minute) + " * * * *", nil
+	case r.Hour != 0:
+		if 24%r.Hour != 0 {
+			return "", fmt.Errorf("cron can't express every %d hours, it doesn't divide a day", r.Hour)
+		}
+
+		return "0 " + step(r.Hour) + " * * *", nil
+	case r.Day != 0:
+		if r.Day != 1 {
+			return "", fmt.Errorf("cron can't express every %d days, months have different lengths", r.Day)
+		}
+
+		return "0 0 * * *", nil
+	case r.Week != 0:
+		if r.Week != 1 {
+			return "", fmt.Errorf("cron can't express every %d weeks", r.Week)
+		}
+
+		return "0 0 * * 0", nil
+	case r.Month != 0:
+		if 12%r.Month != 0 {
+			return "", fmt.Errorf("cron can't express every %d months, it doesn't divide a year", r.Month)
+		}
+
+		return "0 0 1 " + step(r.Month) + " *", nil
+	default:
+		if r.Year != 1 {
+			return "", fmt.Errorf("cron can't express every %d years", r.Year)
+		}
+
+		return "0 0 1 1 *", nil
+	}
+}
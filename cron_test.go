@@ -0,0 +1,67 @@
+package isoperiod_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestToCron(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Cron string
+		Err  error
+	}{
+		{S: "PT1M", Cron: "* * * * *"},
+		{S: "R/PT15M", Cron: "*/15 * * * *"},
+		{S: "PT1H", Cron: "0 * * * *"},
+		{S: "PT6H", Cron: "0 */6 * * *"},
+		{S: "P1D", Cron: "0 0 * * *"},
+		{S: "P1W", Cron: "0 0 * * 0"},
+		{S: "P1M", Cron: "0 0 1 * *"},
+		{S: "P3M", Cron: "0 0 1 */3 *"},
+		{S: "P1Y", Cron: "0 0 1 1 *"},
+		{S: "PT30S", Err: errors.New("cron doesn't support sub-minute precision")},
+		{S: "PT1M0.5S", Err: errors.New("cron doesn't support sub-minute precision")},
+		{S: "PT1H30M", Err: errors.New("cron can't express periods with mixed units")},
+		{S: "R5/PT1H", Err: errors.New("cron can't limit the amount of repetitions")},
+		{S: "-PT1H", Err: errors.New("cron can't express negative periods")},
+		{S: "PT0S", Err: errors.New("cron can't express an empty period")},
+		{S: "PT7M", Err: errors.New("cron can't express every 7 minutes, it doesn't divide an hour")},
+		{S: "PT5H", Err: errors.New("cron can't express every 5 hours, it doesn't divide a day")},
+		{S: "P2D", Err: errors.New("cron can't express every 2 days, months have different lengths")},
+		{S: "P5M", Err: errors.New("cron can't express every 5 months, it doesn't divide a year")},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		cron, err := p.ToCron()
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Errorf("%s: %s", testCase.S, err)
+			continue
+		}
+
+		if cron != testCase.Cron {
+			t.Errorf("%s: cron is %q but should be %q", testCase.S, cron, testCase.Cron)
+		}
+	}
+
+	for _, s := range []string{"PT-15M", "P1DT-1H", "P-1M"} {
+		p, err := isoperiod.ParseWithMode(s, isoperiod.ModeExtended)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		_, err = p.ToCron()
+		if err := checkError(errors.New("cron can't express negative components"), err); err != nil {
+			t.Errorf("%s: %s", s, err)
+		}
+	}
+}
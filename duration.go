@@ -0,0 +1,173 @@
+package isoperiod
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// The lengths used to approximate calendar components as a time.Duration.
+const (
+	ApproxDay   = 24 * time.Hour
+	ApproxWeek  = 7 * ApproxDay
+	ApproxMonth = 30 * ApproxDay
+	ApproxYear  = 365 * ApproxDay
+)
+
+// ParseDuration converts an ISO 8601 period string directly to a time.Duration.
+//
+// Calendar components don't have a fixed length, so they are approximated
+// as described in ApproxDuration.
+//
+// Strings with repetitions (e.g. R5/PT1M) are rejected, since a duration can't represent them.
+func ParseDuration(s string) (time.Duration, error) {
+	if strings.ContainsRune(s, '/') {
+		return 0, fmt.Errorf("%w: duration can't contain repetitions", ErrInvalidFormat)
+	}
+
+	p, err := Parse(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return p.ApproxDuration(), nil
+}
+
+// ParseFlexible converts either an ISO 8601 string or a Go duration string (see
+// time.ParseDuration) to a period. ISO 8601 is tried first, so existing periods
+// keep their meaning.
+//
+// Go durations are mapped onto hours, minutes and seconds: 90m becomes PT1H30M.
+// Beware of the ambiguity of "m": 1m is one minute, while P1M is one month.
+func ParseFlexible(s string) (*Period, error) {
+	p, err := Parse(s)
+	if err == nil {
+		return p, nil
+	}
+
+	d, derr := time.ParseDuration(s)
+	if derr != nil {
+		return nil, err
+	}
+
+	return FromDuration(d), nil
+}
+
+// FromDuration returns a period of hours, minutes and seconds spanning d.
+// It is the inverse of Duration.
+//
+// Durations of 24 hours and more are kept in hours, since a day isn't always
+// 24 hours long: 36*time.Hour becomes PT36H, not P1DT12H.
+// Negative durations result in a negative period.
+func FromDuration(d time.Duration) *Period {
+	p := &Period{}
+
+	// The components are negated one by one, since -d overflows for math.MinInt64.
+	sign := 1
+	if d < 0 {
+		p.Negative = true
+		sign = -1
+	}
+
+	p.Hour = sign * int(d/time.Hour)
+	p.Minute = sign * int(d%time.Hour/time.Minute)
+	p.Second = sign * int(d%time.Minute/time.Second)
+	p.Nanosecond = sign * int(d%time.Second)
+
+	return p
+}
+
+// Duration returns the time portion (hours, minutes and seconds) of the period.
+//
+// Calendar components (years, months, weeks and days) are not included,
+// since they don't have a fixed length. P1DT2H returns 2 hours.
+// Negative periods return a negative duration.
+func (r *Period) Duration() time.Duration {
+	return time.Duration(r.sign()) * r.hms()
+}
+
+// TotalSeconds returns the length of a period consisting only of hours, minutes and seconds
+// in seconds. Fractions of a second are truncated.
+//
+// If the period has calendar components (years, months, weeks or days), ok is false.
+func (r *Period) TotalSeconds() (seconds int64, ok bool) {
+	if r.Year != 0 || r.Month != 0 || r.Week != 0 || r.Day != 0 {
+		return 0, false
+	}
+
+	seconds = int64(r.Hour)*3600 + int64(r.Minute)*60 + int64(r.Second)
+
+	return int64(r.sign()) * seconds, true
+}
+
+// ApproxDuration returns the estimated total length of the period.
+//
+// Calendar components are approximated using ApproxYear (365 days), ApproxMonth (30 days),
+// ApproxWeek (7 days) and ApproxDay (24 hours).
+// Periods exceeding the range of a time.Duration are saturated to math.MaxInt64
+// (or -math.MaxInt64 for negative periods).
+func (r *Period) ApproxDuration() time.Duration {
+	d := mulSaturated(r.Year, ApproxYear)
+	d = addSaturated(d, mulSaturated(r.Month, ApproxMonth))
+	d = addSaturated(d, mulSaturated(r.Week, ApproxWeek))
+	d = addSaturated(d, mulSaturated(r.Day, ApproxDay))
+	d = addSaturated(d, mulSaturated(r.Hour, time.Hour))
+	d = addSaturated(d, mulSaturated(r.Minute, time.Minute))
+	d = addSaturated(d, mulSaturated(r.Second, time.Second))
+	d = addSaturated(d, time.Duration(r.Nanosecond))
+
+	// Keep the range symmetric, so the sign can be applied without overflowing.
+	if d == math.MinInt64 {
+		d = -math.MaxInt64
+	}
+
+	return time.Duration(r.sign()) * d
+}
+
+// addComponent returns d + n*unit, or ErrComponentOverflow if the result exceeds
+// the range of a time.Duration.
+func addComponent(d time.Duration, name string, n int, unit time.Duration) (time.Duration, error) {
+	upper, lower := int64(math.MaxInt64), int64(math.MinInt64)
+	if d > 0 {
+		upper -= int64(d)
+	} else {
+		lower -= int64(d)
+	}
+
+	if int64(n) > upper/int64(unit) || int64(n) < lower/int64(unit) {
+		return 0, fmt.Errorf("%w: %s %d exceeds the range of a duration", ErrComponentOverflow, name, n)
+	}
+
+	return d + time.Duration(n)*unit, nil
+}
+
+// mulSaturated returns n*unit, clamped to the range of a time.Duration.
+func mulSaturated(n int, unit time.Duration) time.Duration {
+	if n == 0 {
+		return 0
+	}
+
+	if int64(n) > math.MaxInt64/int64(unit) {
+		return math.MaxInt64
+	}
+
+	if int64(n) < math.MinInt64/int64(unit) {
+		return math.MinInt64
+	}
+
+	return time.Duration(n) * unit
+}
+
+// addSaturated returns a+b, clamped to the range of a time.Duration.
+func addSaturated(a, b time.Duration) time.Duration {
+	if b > 0 && a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+
+	if b < 0 && a < math.MinInt64-b {
+		return math.MinInt64
+	}
+
+	return a + b
+}
@@ -0,0 +1,318 @@
+package isoperiod_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestParseDuration(t *testing.T) {
+	testTable := []struct {
+		S        string
+		Duration time.Duration
+		Err      error
+	}{
+		{
+			S:        "PT1H30M",
+			Duration: 90 * time.Minute,
+			Err:      nil,
+		},
+		{
+			S:        "P1DT12H",
+			Duration: 36 * time.Hour,
+			Err:      nil,
+		},
+		{
+			S:        "P2W",
+			Duration: 14 * 24 * time.Hour,
+			Err:      nil,
+		},
+		{
+			S:        "P1Y1M",
+			Duration: (365 + 30) * 24 * time.Hour,
+			Err:      nil,
+		},
+		{
+			S:   "R5/PT1M",
+			Err: errors.New("invalid period format: duration can't contain repetitions"),
+		},
+		{
+			S:   "R/PT1M",
+			Err: errors.New("invalid period format: duration can't contain repetitions"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		d, err := isoperiod.ParseDuration(testCase.S)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if d != testCase.Duration {
+			t.Errorf("%s: duration is %s but should be %s", testCase.S, d, testCase.Duration)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	testTable := []struct {
+		Period   *isoperiod.Period
+		Duration time.Duration
+	}{
+		{
+			Period:   isoperiod.New(now, isoperiod.WithHours(1), isoperiod.WithMinutes(30)),
+			Duration: 90 * time.Minute,
+		},
+		{
+			Period:   isoperiod.New(now, isoperiod.WithRepetitions(5), isoperiod.WithYears(1), isoperiod.WithMonths(2), isoperiod.WithDays(3), isoperiod.WithSeconds(10)),
+			Duration: 10 * time.Second,
+		},
+		{
+			Period:   isoperiod.New(now, isoperiod.WithYears(1)),
+			Duration: 0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		if d := testCase.Period.Duration(); d != testCase.Duration {
+			t.Errorf("%s: duration is %s but should be %s", testCase.Period, d, testCase.Duration)
+		}
+	}
+
+	p, err := isoperiod.Parse("P1DT2H3M4S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d, want := p.Duration(), 2*time.Hour+3*time.Minute+4*time.Second; d != want {
+		t.Errorf("duration is %s but should be %s", d, want)
+	}
+}
+
+func TestApproxDuration(t *testing.T) {
+	testTable := []struct {
+		Period   *isoperiod.Period
+		Duration time.Duration
+	}{
+		{
+			Period:   isoperiod.New(now, isoperiod.WithHours(1), isoperiod.WithMinutes(30)),
+			Duration: 90 * time.Minute,
+		},
+		{
+			Period:   isoperiod.New(now, isoperiod.WithYears(1)),
+			Duration: isoperiod.ApproxYear,
+		},
+		{
+			Period:   isoperiod.New(now, isoperiod.WithMonths(2), isoperiod.WithDays(3), isoperiod.WithHours(4)),
+			Duration: 2*isoperiod.ApproxMonth + 3*isoperiod.ApproxDay + 4*time.Hour,
+		},
+		{
+			Period:   isoperiod.New(now, isoperiod.WithYears(1000)),
+			Duration: math.MaxInt64,
+		},
+		{
+			Period:   isoperiod.New(now, isoperiod.WithYears(290), isoperiod.WithDays(1000)),
+			Duration: math.MaxInt64,
+		},
+		{
+			Period:   &isoperiod.Period{Hour: 2 * 2562047},
+			Duration: math.MaxInt64,
+		},
+		{
+			Period:   &isoperiod.Period{Hour: 2562047, Minute: math.MaxInt},
+			Duration: math.MaxInt64,
+		},
+		{
+			Period:   &isoperiod.Period{Negative: true, Hour: 2 * 2562047},
+			Duration: -math.MaxInt64,
+		},
+		{
+			Period:   &isoperiod.Period{Negative: true, Year: math.MinInt},
+			Duration: math.MaxInt64,
+		},
+	}
+
+	for _, testCase := range testTable {
+		if d := testCase.Period.ApproxDuration(); d != testCase.Duration {
+			t.Errorf("%s: duration is %s but should be %s", testCase.Period, d, testCase.Duration)
+		}
+	}
+}
+
+func TestApproxDurationScaled(t *testing.T) {
+	p, err := isoperiod.Parse("PT2562047H")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := p.Scale(2).ApproxDuration(); d != math.MaxInt64 {
+		t.Errorf("duration is %s but should be %s", d, time.Duration(math.MaxInt64))
+	}
+}
+
+func TestParseDurationNegative(t *testing.T) {
+	d, err := isoperiod.ParseDuration("-P1DT1H")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := -25 * time.Hour; d != want {
+		t.Errorf("duration is %s but should be %s", d, want)
+	}
+}
+
+func TestTotalSeconds(t *testing.T) {
+	testTable := []struct {
+		S       string
+		Seconds int64
+		OK      bool
+	}{
+		{
+			S:       "PT1H30M",
+			Seconds: 5400,
+			OK:      true,
+		},
+		{
+			S:       "PT1.5S",
+			Seconds: 1,
+			OK:      true,
+		},
+		{
+			S:       "-PT1M",
+			Seconds: -60,
+			OK:      true,
+		},
+		{
+			S:  "P1M",
+			OK: false,
+		},
+		{
+			S:  "P1DT1H",
+			OK: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		seconds, ok := p.TotalSeconds()
+		if ok != testCase.OK {
+			t.Errorf("%s: ok is %t but should be %t", testCase.S, ok, testCase.OK)
+		}
+
+		if seconds != testCase.Seconds {
+			t.Errorf("%s: seconds are %d but should be %d", testCase.S, seconds, testCase.Seconds)
+		}
+	}
+}
+
+func TestParseFlexible(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Want string
+		Err  error
+	}{
+		{
+			S:    "P1M",
+			Want: "P1M",
+		},
+		{
+			S:    "R5/PT30S",
+			Want: "R5/PT30S",
+		},
+		{
+			S:    "90m",
+			Want: "PT1H30M",
+		},
+		{
+			S:    "1h30m",
+			Want: "PT1H30M",
+		},
+		{
+			S:    "1m",
+			Want: "PT1M",
+		},
+		{
+			S:    "1.5s",
+			Want: "PT1.5S",
+		},
+		{
+			S:    "-2h",
+			Want: "-PT2H",
+		},
+		{
+			S:   "foo",
+			Err: errors.New("parsing \"foo\" at offset 0: invalid period format"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.ParseFlexible(testCase.S)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		if s := p.String(); s != testCase.Want {
+			t.Errorf("%s: period is %s but should be %s", testCase.S, s, testCase.Want)
+		}
+	}
+}
+
+func TestFromDuration(t *testing.T) {
+	testTable := []struct {
+		Duration time.Duration
+		S        string
+	}{
+		{
+			Duration: 90 * time.Minute,
+			S:        "PT1H30M",
+		},
+		{
+			Duration: 36 * time.Hour,
+			S:        "PT36H",
+		},
+		{
+			Duration: 1500 * time.Millisecond,
+			S:        "PT1.5S",
+		},
+		{
+			Duration: -2 * time.Hour,
+			S:        "-PT2H",
+		},
+		{
+			Duration: 0,
+			S:        "PT0S",
+		},
+		{
+			Duration: math.MinInt64,
+			S:        "-PT2562047H47M16.854775808S",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p := isoperiod.FromDuration(testCase.Duration)
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("%s: period is %s but should be %s", testCase.Duration, s, testCase.S)
+		}
+
+		if d := p.Duration(); d != testCase.Duration {
+			t.Errorf("%s: duration is %s but should be %s", testCase.S, d, testCase.Duration)
+		}
+	}
+}
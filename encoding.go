@@ -0,0 +1,261 @@
+package isoperiod
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// A JSONMode selects the representation used by MarshalJSON.
+type JSONMode int32
+
+const (
+	// JSONString encodes periods as ISO 8601 strings like "R5/PT30S". This is the default.
+	JSONString JSONMode = iota
+
+	// JSONObject encodes periods as objects with the numeric fields,
+	// like {"repetitions":5,...,"second":30,...}.
+	JSONObject
+)
+
+var jsonMode atomic.Int32
+
+// SetJSONMode selects the representation used by MarshalJSON for all periods.
+//
+// UnmarshalJSON accepts both representations regardless of the mode, so consumers
+// can be migrated before the producers switch. Consumers relying on the object form
+// have to select JSONObject, since the default is the ISO 8601 string.
+func SetJSONMode(mode JSONMode) {
+	jsonMode.Store(int32(mode))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The period is encoded as its ISO 8601 string, or as an object, see SetJSONMode.
+func (r *Period) MarshalJSON() ([]byte, error) {
+	if JSONMode(jsonMode.Load()) == JSONObject {
+		// object has the same fields as Period, but none of its methods.
+		type object Period
+
+		return json.Marshal((*object)(r))
+	}
+
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// The period can either be an ISO 8601 string like "R5/PT30S" or
+// an object with the numeric fields. A JSON null leaves the period untouched.
+func (r *Period) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+
+		p, err := Parse(s)
+		if err != nil {
+			return err
+		}
+
+		r.assign(p)
+
+		return nil
+	}
+
+	// object has the same fields as Period, but none of its methods.
+	type object Period
+
+	var o object
+	if err := json.Unmarshal(data, &o); err != nil {
+		return err
+	}
+
+	r.assign((*Period)(&o))
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r *Period) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Period) UnmarshalText(text []byte) error {
+	p, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	r.assign(p)
+
+	return nil
+}
+
+// Set implements the flag.Value interface, so periods can be used as command-line flags:
+//
+//	p := isoperiod.New(time.Now(), isoperiod.WithMinutes(5))
+//	flag.Var(p, "interval", "ISO 8601 period or seconds")
+//
+// Besides ISO 8601 strings, a bare integer is accepted as number of seconds.
+func (r *Period) Set(s string) error {
+	if n, err := strconv.Atoi(s); err == nil {
+		p := &Period{Second: n}
+		if n < 0 {
+			p.Negative = true
+			p.Second = -n
+		}
+
+		r.assign(p)
+
+		return nil
+	}
+
+	return r.UnmarshalText([]byte(s))
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts strings and byte slices. A NULL value resets the period to its zero value.
+func (r *Period) Scan(src any) error {
+	var s string
+
+	switch v := src.(type) {
+	case nil:
+		r.assign(&Period{})
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("can't scan %T into a period", src)
+	}
+
+	p, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	r.assign(p)
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// The period is always stored as its ISO 8601 string, a zero period is never written as NULL.
+func (r *Period) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// MarshalXML implements the xml.Marshaler interface.
+// The period is encoded as the ISO 8601 string in the element text.
+func (r *Period) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(r.String(), start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+// An element with xsi:nil="true" resets the period to its zero value.
+func (r *Period) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local != "nil" || (attr.Name.Space != xsiNamespace && attr.Name.Space != "xsi") {
+			continue
+		}
+
+		if attr.Value == "true" || attr.Value == "1" {
+			r.assign(&Period{})
+			return d.Skip()
+		}
+	}
+
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	p, err := Parse(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+
+	r.assign(p)
+
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+// Only the components are encoded, using the ISO 8601 string.
+func (r *Period) GobEncode() ([]byte, error) {
+	return r.MarshalText()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (r *Period) GobDecode(data []byte) error {
+	return r.UnmarshalText(data)
+}
+
+// binaryVersion is the first byte of the MarshalBinary encoding.
+const binaryVersion = 1
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The encoding starts with a version byte and a flags byte (1 for negative periods),
+// followed by the repetitions and all components as signed varints.
+func (r *Period) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 2, 2+9*binary.MaxVarintLen64)
+	data[0] = binaryVersion
+	if r.Negative {
+		data[1] = 1
+	}
+
+	for _, n := range []int{r.Repetitions, r.Year, r.Month, r.Week, r.Day, r.Hour, r.Minute, r.Second, r.Nanosecond} {
+		data = binary.AppendVarint(data, int64(n))
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (r *Period) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errors.New("binary period is too short")
+	}
+
+	if data[0] != binaryVersion {
+		return fmt.Errorf("unsupported binary period version %d", data[0])
+	}
+
+	p := &Period{Negative: data[1]&1 != 0}
+	data = data[2:]
+
+	for _, field := range []*int{&p.Repetitions, &p.Year, &p.Month, &p.Week, &p.Day, &p.Hour, &p.Minute, &p.Second, &p.Nanosecond} {
+		n, size := binary.Varint(data)
+		if size <= 0 || int64(int(n)) != n {
+			return errors.New("invalid binary period")
+		}
+
+		*field = int(n)
+		data = data[size:]
+	}
+
+	if len(data) != 0 {
+		return errors.New("invalid binary period")
+	}
+
+	r.assign(p)
+
+	return nil
+}
@@ -0,0 +1,499 @@
+package isoperiod_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+	testTable := []struct {
+		JSON        string
+		Repetitions int
+		Year        int
+		Month       int
+		Hour        int
+		Second      int
+		Err         error
+	}{
+		{
+			JSON:  `"P1Y6M"`,
+			Year:  1,
+			Month: 6,
+			Err:   nil,
+		},
+		{
+			JSON:        `"R5/PT30S"`,
+			Repetitions: 5,
+			Second:      30,
+			Err:         nil,
+		},
+		{
+			JSON:        `{"repetitions":2,"year":1,"hour":3}`,
+			Repetitions: 2,
+			Year:        1,
+			Hour:        3,
+			Err:         nil,
+		},
+		{
+			JSON: `null`,
+			Err:  nil,
+		},
+		{
+			JSON: `"P"`,
+			Err:  errors.New("parsing \"P\" at offset 1: period has no components"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		var p isoperiod.Period
+		err := json.Unmarshal([]byte(testCase.JSON), &p)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if p.Repetitions != testCase.Repetitions {
+			t.Errorf("%s: Repetitions %d != %d", testCase.JSON, p.Repetitions, testCase.Repetitions)
+		}
+
+		if p.Year != testCase.Year {
+			t.Errorf("%s: Year %d != %d", testCase.JSON, p.Year, testCase.Year)
+		}
+
+		if p.Month != testCase.Month {
+			t.Errorf("%s: Month %d != %d", testCase.JSON, p.Month, testCase.Month)
+		}
+
+		if p.Hour != testCase.Hour {
+			t.Errorf("%s: Hour %d != %d", testCase.JSON, p.Hour, testCase.Hour)
+		}
+
+		if p.Second != testCase.Second {
+			t.Errorf("%s: Second %d != %d", testCase.JSON, p.Second, testCase.Second)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	testTable := []struct {
+		S    string
+		JSON string
+	}{
+		{
+			S:    "R5/PT30S",
+			JSON: `"R5/PT30S"`,
+		},
+		{
+			S:    "R3/P1Y6M",
+			JSON: `"R3/P1Y6M"`,
+		},
+		{
+			S:    "R2/P2W",
+			JSON: `"R2/P2W"`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if string(data) != testCase.JSON {
+			t.Errorf("json is %s but should be %s", data, testCase.JSON)
+		}
+
+		var decoded isoperiod.Period
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if decoded.String() != p.String() {
+			t.Errorf("round-trip is %s but should be %s", decoded.String(), p.String())
+		}
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	testTable := []string{
+		"R5/PT30S",
+		"R3/P1Y6M2DT2H",
+		"R1/P2W",
+		"R/PT1M",
+		"P1D",
+	}
+
+	for _, s := range testTable {
+		p, err := isoperiod.Parse(s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		var m encoding.TextMarshaler = p
+		text, err := m.MarshalText()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		var decoded isoperiod.Period
+		var u encoding.TextUnmarshaler = &decoded
+		if err := u.UnmarshalText(text); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if decoded.String() != p.String() {
+			t.Errorf("round-trip is %s but should be %s", decoded.String(), p.String())
+		}
+	}
+
+	var p isoperiod.Period
+	if err := p.UnmarshalText([]byte("P1Mfoo")); err == nil {
+		t.Error("error is nil but should be set")
+	}
+}
+
+func TestScan(t *testing.T) {
+	testTable := []struct {
+		Src any
+		S   string
+		Err error
+	}{
+		{
+			Src: "R5/PT30S",
+			S:   "R5/PT30S",
+			Err: nil,
+		},
+		{
+			Src: []byte("R2/P1Y6M"),
+			S:   "R2/P1Y6M",
+			Err: nil,
+		},
+		{
+			Src: nil,
+			S:   isoperiod.New(now).String(),
+			Err: nil,
+		},
+		{
+			Src: 42,
+			Err: errors.New("can't scan int into a period"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p := isoperiod.New(now, isoperiod.WithRepetitions(3), isoperiod.WithDays(1))
+		err := p.Scan(testCase.Src)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		v, err := p.Value()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if v != testCase.S {
+			t.Errorf("value is %v but should be %s", v, testCase.S)
+		}
+	}
+}
+
+func TestXML(t *testing.T) {
+	type job struct {
+		XMLName  xml.Name          `xml:"job"`
+		Duration *isoperiod.Period `xml:"duration"`
+	}
+
+	p, err := isoperiod.Parse("P1Y2M")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := xml.Marshal(job{Duration: p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "<job><duration>P1Y2M</duration></job>"; string(data) != want {
+		t.Errorf("xml is %s but should be %s", data, want)
+	}
+
+	testTable := []struct {
+		XML string
+		S   string
+	}{
+		{
+			XML: "<job><duration>R5/PT30S</duration></job>",
+			S:   "R5/PT30S",
+		},
+		{
+			XML: `<job xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><duration xsi:nil="true"/></job>`,
+			S:   "PT0S",
+		},
+	}
+
+	for _, testCase := range testTable {
+		v := job{Duration: isoperiod.New(now, isoperiod.WithDays(1))}
+		if err := xml.Unmarshal([]byte(testCase.XML), &v); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := v.Duration.String(); s != testCase.S {
+			t.Errorf("period is %s but should be %s", s, testCase.S)
+		}
+	}
+
+	var v job
+	if err := xml.Unmarshal([]byte("<job><duration>P1Mfoo</duration></job>"), &v); err == nil {
+		t.Error("error is nil but should be set")
+	}
+}
+
+func TestGob(t *testing.T) {
+	testTable := []string{
+		"R5/PT30S",
+		"P1Y6M2DT2H",
+		"R/-PT1.5S",
+		"P3W",
+	}
+
+	for _, s := range testTable {
+		p, err := isoperiod.Parse(s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(p); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		var decoded isoperiod.Period
+		if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !decoded.Equal(p) {
+			t.Errorf("decoded is %s but should be %s", decoded.String(), p)
+		}
+	}
+}
+
+func TestJSONMode(t *testing.T) {
+	defer isoperiod.SetJSONMode(isoperiod.JSONString)
+
+	testTable := []struct {
+		Mode isoperiod.JSONMode
+		S    string
+		JSON string
+	}{
+		{
+			Mode: isoperiod.JSONString,
+			S:    "R5/P1DT30.5S",
+			JSON: `"R5/P1DT30.5S"`,
+		},
+		{
+			Mode: isoperiod.JSONObject,
+			S:    "R5/P1DT30.5S",
+			JSON: `{"repetitions":5,"year":0,"month":0,"week":0,"day":1,"hour":0,"minute":0,"second":30,"nanosecond":500000000,"negative":false}`,
+		},
+		{
+			Mode: isoperiod.JSONObject,
+			S:    "R/-PT1M",
+			JSON: `{"repetitions":-1,"year":0,"month":0,"week":0,"day":0,"hour":0,"minute":1,"second":0,"nanosecond":0,"negative":true}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		isoperiod.SetJSONMode(testCase.Mode)
+
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if string(data) != testCase.JSON {
+			t.Errorf("json is %s but should be %s", data, testCase.JSON)
+		}
+
+		var decoded isoperiod.Period
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !decoded.Equal(p) {
+			t.Errorf("round-trip is %s but should be %s", decoded.String(), p)
+		}
+	}
+}
+
+func TestFlag(t *testing.T) {
+	testTable := []struct {
+		Args []string
+		S    string
+		Err  error
+	}{
+		{
+			Args: []string{},
+			S:    "PT5M",
+		},
+		{
+			Args: []string{"-interval", "R/PT30S"},
+			S:    "R/PT30S",
+		},
+		{
+			Args: []string{"-interval=P1DT2H"},
+			S:    "P1DT2H",
+		},
+		{
+			Args: []string{"-interval", "90"},
+			S:    "PT90S",
+		},
+		{
+			Args: []string{"-interval", "-15"},
+			S:    "-PT15S",
+		},
+		{
+			Args: []string{"-interval", "P1Mfoo"},
+			Err:  errors.New(`invalid value "P1Mfoo" for flag -interval: parsing "P1Mfoo" at offset 3: invalid period format`),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p := isoperiod.New(now, isoperiod.WithMinutes(5))
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(p, "interval", "ISO 8601 period or seconds")
+
+		err := fs.Parse(testCase.Args)
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("%v: period is %s but should be %s", testCase.Args, s, testCase.S)
+		}
+	}
+}
+
+func TestBinary(t *testing.T) {
+	testTable := []string{
+		"R5/PT30S",
+		"P1Y6M2DT2H",
+		"R/-PT1.5S",
+		"P3W",
+		"PT0S",
+		"R1000000/P99999Y",
+	}
+
+	for _, s := range testTable {
+		p, err := isoperiod.Parse(s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		var m encoding.BinaryMarshaler = p
+		data, err := m.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		var decoded isoperiod.Period
+		var u encoding.BinaryUnmarshaler = &decoded
+		if err := u.UnmarshalBinary(data); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !decoded.Equal(p) {
+			t.Errorf("decoded is %s but should be %s", decoded.String(), p)
+		}
+	}
+
+	extended, err := isoperiod.ParseWithMode("P1Y-2M", isoperiod.ModeExtended)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := extended.MarshalBinary()
+	var decoded isoperiod.Period
+	if err := decoded.UnmarshalBinary(data); err != nil || !decoded.Equal(extended) {
+		t.Errorf("decoded is %s (%v) but should be %s", decoded.String(), err, extended)
+	}
+
+	errorTable := []struct {
+		Data []byte
+		Err  error
+	}{
+		{
+			Data: nil,
+			Err:  errors.New("binary period is too short"),
+		},
+		{
+			Data: []byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			Err:  errors.New("unsupported binary period version 2"),
+		},
+		{
+			Data: []byte{1, 0, 0, 0},
+			Err:  errors.New("invalid binary period"),
+		},
+		{
+			Data: []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			Err:  errors.New("invalid binary period"),
+		},
+	}
+
+	for _, testCase := range errorTable {
+		var p isoperiod.Period
+		if err := checkError(testCase.Err, p.UnmarshalBinary(testCase.Data)); err != nil {
+			t.Errorf("%v: %s", testCase.Data, err)
+		}
+	}
+}
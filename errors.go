@@ -0,0 +1,61 @@
+package isoperiod
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// ErrInvalidFormat is returned for strings that aren't valid periods.
+	ErrInvalidFormat = errors.New("invalid period format")
+
+	// ErrEmptyPeriod is returned for periods without any components, like P or PT.
+	ErrEmptyPeriod = errors.New("period has no components")
+
+	// ErrComponentOverflow is returned for components too large to be represented.
+	ErrComponentOverflow = errors.New("component overflow")
+
+	// ErrOutOfRange is returned by Validate for components outside of their strict range.
+	ErrOutOfRange = errors.New("component out of range")
+
+	// ErrWeekWithOtherFields is returned by Validate for periods combining weeks with
+	// years, months or days.
+	ErrWeekWithOtherFields = errors.New("week can't be combined with other date components")
+)
+
+// A ParseError describes a failure to parse a period string.
+type ParseError struct {
+	// Input is the string that was being parsed.
+	Input string
+
+	// Offset is the byte offset in Input at which parsing failed.
+	Offset int
+
+	// Err is the underlying error, e.g. ErrInvalidFormat.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing %q at offset %d: %s", e.Input, e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// atoi converts the digits of the named component, reporting an ErrComponentOverflow
+// if they don't fit into an int.
+func atoi(name string, digits string) (int, error) {
+	if digits == "" {
+		return 0, fmt.Errorf("%w: %s without digits", ErrInvalidFormat, name)
+	}
+
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s %s", ErrComponentOverflow, name, digits)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,144 @@
+package isoperiod_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestErrors(t *testing.T) {
+	testTable := []struct {
+		S   string
+		Err error
+	}{
+		{
+			S:   "nonsense",
+			Err: isoperiod.ErrInvalidFormat,
+		},
+		{
+			S:   "P1DT",
+			Err: isoperiod.ErrInvalidFormat,
+		},
+		{
+			S:   "P",
+			Err: isoperiod.ErrEmptyPeriod,
+		},
+		{
+			S:   "P99999999999999999999Y",
+			Err: isoperiod.ErrComponentOverflow,
+		},
+		{
+			S:   "PT9999999999S",
+			Err: isoperiod.ErrComponentOverflow,
+		},
+		{
+			S:   "PT3000000H",
+			Err: isoperiod.ErrComponentOverflow,
+		},
+		{
+			S:   "PT2562047H60M",
+			Err: isoperiod.ErrComponentOverflow,
+		},
+		{
+			S:   "PT2562047H47M16.854775808S",
+			Err: isoperiod.ErrComponentOverflow,
+		},
+		{
+			S:   "R99999999999999999999/P1Y",
+			Err: isoperiod.ErrComponentOverflow,
+		},
+	}
+
+	for _, testCase := range testTable {
+		_, err := isoperiod.Parse(testCase.S)
+		if !errors.Is(err, testCase.Err) {
+			t.Errorf("%s: error is %v but should be %q", testCase.S, err, testCase.Err.Error())
+		}
+	}
+
+	if _, err := isoperiod.ParseStrict("PT60M"); !errors.Is(err, isoperiod.ErrOutOfRange) {
+		t.Errorf("error is %v but should be %q", err, isoperiod.ErrOutOfRange.Error())
+	}
+
+	if _, err := isoperiod.ParseDuration("R/PT1M"); !errors.Is(err, isoperiod.ErrInvalidFormat) {
+		t.Errorf("error is %v but should be %q", err, isoperiod.ErrInvalidFormat.Error())
+	}
+
+	_, err := isoperiod.Parse("P99999999999999999999Y")
+	if want := `parsing "P99999999999999999999Y" at offset 1: component overflow: year 99999999999999999999`; err == nil || err.Error() != want {
+		t.Errorf("error is %v but should be %q", err, want)
+	}
+
+	_, err = isoperiod.Parse("PT9999999999S")
+	if want := `parsing "PT9999999999S" at offset 2: component overflow: second 9999999999 exceeds the range of a duration`; err == nil || err.Error() != want {
+		t.Errorf("error is %v but should be %q", err, want)
+	}
+
+	// The largest representable duration is still accepted.
+	p, err := isoperiod.Parse("PT2562047H47M16.854775807S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := p.Duration(); d != math.MaxInt64 {
+		t.Errorf("duration is %d but should be %d", d, int64(math.MaxInt64))
+	}
+}
+
+func TestParseError(t *testing.T) {
+	testTable := []struct {
+		S      string
+		Offset int
+		Err    error
+	}{
+		{
+			S:      "P1Mfoo",
+			Offset: 3,
+			Err:    isoperiod.ErrInvalidFormat,
+		},
+		{
+			S:      "xxP1M",
+			Offset: 0,
+			Err:    isoperiod.ErrInvalidFormat,
+		},
+		{
+			S:      "P1D2H",
+			Offset: 3,
+			Err:    isoperiod.ErrInvalidFormat,
+		},
+		{
+			S:      "PT",
+			Offset: 2,
+			Err:    isoperiod.ErrEmptyPeriod,
+		},
+		{
+			S:      "R5/P1DT99999999999999999999S",
+			Offset: 7,
+			Err:    isoperiod.ErrComponentOverflow,
+		},
+	}
+
+	for _, testCase := range testTable {
+		_, err := isoperiod.Parse(testCase.S)
+
+		var perr *isoperiod.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: error is %v but should be a *ParseError", testCase.S, err)
+			continue
+		}
+
+		if perr.Input != testCase.S {
+			t.Errorf("%s: input is %q but should be %q", testCase.S, perr.Input, testCase.S)
+		}
+
+		if perr.Offset != testCase.Offset {
+			t.Errorf("%s: offset is %d but should be %d", testCase.S, perr.Offset, testCase.Offset)
+		}
+
+		if !errors.Is(err, testCase.Err) {
+			t.Errorf("%s: error is %v but should be %q", testCase.S, err, testCase.Err.Error())
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package isoperiod
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatOptions control the output of Format.
+type FormatOptions struct {
+	// ZeroRepetitions emits an explicit "R0/" prefix for periods without repetitions.
+	ZeroRepetitions bool
+
+	// AlwaysTime emits the time portion even if the period has date components,
+	// falling back to T0S if there are no time components.
+	AlwaysTime bool
+
+	// Comma uses a comma instead of a dot as decimal sign.
+	Comma bool
+
+	// Pad zero-pads each component to at least Pad digits.
+	Pad int
+
+	// Lower emits lowercase designators, e.g. p1y6m.
+	Lower bool
+
+	// NoRepetitions omits the repetitions prefix (e.g. R5/), overriding ZeroRepetitions.
+	NoRepetitions bool
+}
+
+// Format returns the ISO 8601 representation of the period using the given options.
+func (r *Period) Format(opts FormatOptions) string {
+	result := ""
+	dateAdded := false
+
+	number := func(n int) string {
+		sign := ""
+		if n < 0 {
+			sign = "-"
+		}
+
+		s := strconv.FormatUint(uint64(abs(n)), 10)
+		if len(s) < opts.Pad {
+			s = strings.Repeat("0", opts.Pad-len(s)) + s
+		}
+
+		return sign + s
+	}
+
+	switch {
+	case opts.NoRepetitions:
+	case r.Repetitions == Endless:
+		result += "R/"
+	case r.Repetitions > 0 || opts.ZeroRepetitions:
+		result += "R" + strconv.Itoa(r.Repetitions) + "/"
+	}
+
+	if r.Negative {
+		result += "-"
+	}
+
+	result += "P"
+
+	if r.Year != 0 {
+		result += number(r.Year) + "Y"
+		dateAdded = true
+	}
+	if r.Month != 0 {
+		result += number(r.Month) + "M"
+		dateAdded = true
+	}
+	if r.Week != 0 {
+		result += number(r.Week) + "W"
+		dateAdded = true
+	}
+	if r.Day != 0 {
+		result += number(r.Day) + "D"
+		dateAdded = true
+	}
+
+	t := ""
+	if r.Hour != 0 {
+		t += number(r.Hour) + "H"
+	}
+	if r.Minute != 0 {
+		t += number(r.Minute) + "M"
+	}
+	if r.Second != 0 || r.Nanosecond != 0 {
+		// Signed seconds (ModeExtended) carry the sign on the nanoseconds as well,
+		// and whole seconds in the nanoseconds (e.g. set directly) are carried over.
+		sec, ns := r.Second, r.Nanosecond
+		if sec < 0 || (sec == 0 && ns < 0) {
+			t += "-"
+			sec, ns = -sec, -ns
+		}
+		if ns < 0 {
+			sec--
+			ns += int(time.Second)
+		}
+		sec += ns / int(time.Second)
+		ns %= int(time.Second)
+
+		t += number(sec)
+		if ns > 0 {
+			sep := "."
+			if opts.Comma {
+				sep = ","
+			}
+			t += sep + strings.TrimRight(fmt.Sprintf("%09d", ns), "0")
+		}
+		t += "S"
+	}
+
+	if t == "" && (!dateAdded || opts.AlwaysTime) {
+		t = number(0) + "S"
+	}
+
+	if t != "" {
+		result += "T" + t
+	}
+
+	if opts.Lower {
+		result = strings.ToLower(result)
+	}
+
+	return result
+}
+
+// FormatWith returns the ISO 8601 representation of the period, optionally with lowercase
+// designators and each component zero-padded to at least pad digits.
+// It is a shorthand for Format(FormatOptions{Lower: lower, Pad: pad}).
+func (r *Period) FormatWith(lower bool, pad int) string {
+	return r.Format(FormatOptions{Lower: lower, Pad: pad})
+}
+
+// DurationString returns the ISO 8601 representation of the period without
+// the repetitions prefix: R5/P1D results in P1D.
+func (r *Period) DurationString() string {
+	return r.Format(FormatOptions{NoRepetitions: true})
+}
+
+// InUnits returns the non-zero components of the period keyed by their unit name:
+// "year", "month", "week", "day", "hour", "minute", "second" and "nanosecond".
+// Repetitions are not included. For negative periods the counts are negative.
+//
+// P1Y6M results in map[month:6 year:1].
+func (r *Period) InUnits() map[string]int {
+	units := map[string]int{}
+	sign := r.sign()
+
+	for _, c := range r.units() {
+		if c.value != 0 {
+			units[c.name] = sign * c.value
+		}
+	}
+
+	return units
+}
+
+// Components returns the names of the non-zero components in canonical order,
+// using the same names as InUnits. P1Y2MT3M results in [year month minute],
+// a period without components in an empty slice.
+func (r *Period) Components() []string {
+	names := []string{}
+	for _, c := range r.units() {
+		if c.value != 0 {
+			names = append(names, c.name)
+		}
+	}
+
+	return names
+}
+
+// Humanize returns an English description of the period, e.g. "1 year and 6 months"
+// for P1Y6M or "every 1 minute, 5 times" for R5/PT1M. Endless periods are described
+// as "every 1 minute, forever", negative periods start with "minus".
+func (r *Period) Humanize() string {
+	var parts []string
+	for _, c := range r.units() {
+		switch {
+		case c.name == "nanosecond":
+			// Nanoseconds are shown as fraction of the seconds.
+		case c.name == "second" && (c.value != 0 || r.Nanosecond != 0):
+			seconds := (&Period{Second: c.value, Nanosecond: r.Nanosecond}).Format(FormatOptions{})
+			seconds = strings.TrimSuffix(strings.TrimPrefix(seconds, "PT"), "S")
+			parts = append(parts, plural(seconds, c.name))
+		case c.value != 0:
+			parts = append(parts, plural(strconv.Itoa(c.value), c.name))
+		}
+	}
+
+	if len(parts) == 0 {
+		parts = []string{plural("0", "second")}
+	}
+
+	result := parts[0]
+	if len(parts) > 1 {
+		result = strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+	}
+
+	if r.Negative {
+		result = "minus " + result
+	}
+
+	switch {
+	case r.Repetitions == Endless:
+		result = "every " + result + ", forever"
+	case r.Repetitions == 1:
+		result = "every " + result + ", once"
+	case r.Repetitions > 0:
+		result = "every " + result + ", " + strconv.Itoa(r.Repetitions) + " times"
+	}
+
+	return result
+}
+
+// plural returns the count followed by the unit name, pluralized unless the count is 1.
+func plural(count string, name string) string {
+	if count == "1" || count == "-1" {
+		return count + " " + name
+	}
+
+	return count + " " + name + "s"
+}
+
+// unit is a named component of a period.
+type unit struct {
+	name  string
+	value int
+}
+
+// units returns all components of the period in canonical order, without applying the sign.
+func (r *Period) units() []unit {
+	return []unit{
+		{"year", r.Year},
+		{"month", r.Month},
+		{"week", r.Week},
+		{"day", r.Day},
+		{"hour", r.Hour},
+		{"minute", r.Minute},
+		{"second", r.Second},
+		{"nanosecond", r.Nanosecond},
+	}
+}
+
+// abs returns the absolute value of n as uint, so it doesn't overflow for math.MinInt.
+func abs(n int) uint {
+	if n < 0 {
+		return -uint(n)
+	}
+
+	return uint(n)
+}
+
+// IsCanonical reports whether s is a valid period in the form returned by String:
+// no zero or zero-padded components, a dot as decimal sign and no R0/ prefix.
+// Overflowing components are kept by String, so PT90M is canonical, too.
+//
+// P1M is canonical, P01M, P0Y1M and PT0,5S are not.
+func IsCanonical(s string) bool {
+	p, err := Parse(s)
+	if err != nil {
+		return false
+	}
+
+	return p.String() == s
+}
@@ -0,0 +1,406 @@
+package isoperiod_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestFormat(t *testing.T) {
+	testTable := []struct {
+		S       string
+		Options isoperiod.FormatOptions
+		Want    string
+	}{
+		{
+			S:       "R2/P1Y6M",
+			Options: isoperiod.FormatOptions{},
+			Want:    "R2/P1Y6M",
+		},
+		{
+			S:       "R2/P1Y6M",
+			Options: isoperiod.FormatOptions{AlwaysTime: true},
+			Want:    "R2/P1Y6MT0S",
+		},
+		{
+			S:       "R2/PT1.5S",
+			Options: isoperiod.FormatOptions{Comma: true},
+			Want:    "R2/PT1,5S",
+		},
+		{
+			S:       "R2/P1Y6MT5M",
+			Options: isoperiod.FormatOptions{Pad: 2},
+			Want:    "R2/P01Y06MT05M",
+		},
+		{
+			S:       "R2/PT0S",
+			Options: isoperiod.FormatOptions{Pad: 2},
+			Want:    "R2/PT00S",
+		},
+		{
+			S:       "R0/P1D",
+			Options: isoperiod.FormatOptions{},
+			Want:    "P1D",
+		},
+		{
+			S:       "R0/P1D",
+			Options: isoperiod.FormatOptions{ZeroRepetitions: true},
+			Want:    "R0/P1D",
+		},
+		{
+			S:       "R/P1D",
+			Options: isoperiod.FormatOptions{ZeroRepetitions: true},
+			Want:    "R/P1D",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := p.Format(testCase.Options); s != testCase.Want {
+			t.Errorf("%s: formatted is %s but should be %s", testCase.S, s, testCase.Want)
+		}
+	}
+}
+
+func TestFormatWith(t *testing.T) {
+	testTable := []struct {
+		S     string
+		Lower bool
+		Pad   int
+		Want  string
+	}{
+		{
+			S:    "P1Y6M",
+			Want: "P1Y6M",
+		},
+		{
+			S:     "P1Y6M",
+			Lower: true,
+			Want:  "p1y6m",
+		},
+		{
+			S:    "P1Y6M",
+			Pad:  2,
+			Want: "P01Y06M",
+		},
+		{
+			S:     "R5/P1DT12H30M",
+			Lower: true,
+			Pad:   2,
+			Want:  "r5/p01dt12h30m",
+		},
+		{
+			S:    "P100D",
+			Pad:  2,
+			Want: "P100D",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := p.FormatWith(testCase.Lower, testCase.Pad); s != testCase.Want {
+			t.Errorf("%s: formatted is %s but should be %s", testCase.S, s, testCase.Want)
+		}
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("string is %s but should be %s", s, testCase.S)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	testTable := []string{
+		"P1M",
+		"PT1M",
+		"P1Y6M2DT2H",
+		"P3W",
+		"-PT2H",
+		"PT0.5S",
+		"R/PT1M",
+		"R5/P1D",
+	}
+
+	for _, s := range testTable {
+		p, err := isoperiod.Parse(s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if got := p.String(); got != s {
+			t.Errorf("round-trip is %s but should be %s", got, s)
+		}
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Want string
+	}{
+		{
+			S:    "R5/P1D",
+			Want: "P1D",
+		},
+		{
+			S:    "R/-PT1M",
+			Want: "-PT1M",
+		},
+		{
+			S:    "P1Y6M",
+			Want: "P1Y6M",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := p.DurationString(); s != testCase.Want {
+			t.Errorf("%s: duration string is %s but should be %s", testCase.S, s, testCase.Want)
+		}
+	}
+
+	p, _ := isoperiod.Parse("R5/P1D")
+	if s, want := p.Format(isoperiod.FormatOptions{NoRepetitions: true, ZeroRepetitions: true}), "P1D"; s != want {
+		t.Errorf("formatted is %s but should be %s", s, want)
+	}
+}
+
+func TestInUnits(t *testing.T) {
+	testTable := []struct {
+		S     string
+		Units map[string]int
+	}{
+		{
+			S:     "P1Y6M",
+			Units: map[string]int{"year": 1, "month": 6},
+		},
+		{
+			S:     "R5/P2WT1.5S",
+			Units: map[string]int{"week": 2, "second": 1, "nanosecond": 500000000},
+		},
+		{
+			S:     "-P1DT2H",
+			Units: map[string]int{"day": -1, "hour": -2},
+		},
+		{
+			S:     "PT0S",
+			Units: map[string]int{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if units := p.InUnits(); !reflect.DeepEqual(units, testCase.Units) {
+			t.Errorf("%s: units are %v but should be %v", testCase.S, units, testCase.Units)
+		}
+	}
+}
+
+func TestHumanize(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Want string
+	}{
+		{
+			S:    "R5/PT1M",
+			Want: "every 1 minute, 5 times",
+		},
+		{
+			S:    "P1Y6M",
+			Want: "1 year and 6 months",
+		},
+		{
+			S:    "R/P1D",
+			Want: "every 1 day, forever",
+		},
+		{
+			S:    "R1/PT2H",
+			Want: "every 2 hours, once",
+		},
+		{
+			S:    "P2WT1H30M",
+			Want: "2 weeks, 1 hour and 30 minutes",
+		},
+		{
+			S:    "PT1.5S",
+			Want: "1.5 seconds",
+		},
+		{
+			S:    "PT1S",
+			Want: "1 second",
+		},
+		{
+			S:    "-P1D",
+			Want: "minus 1 day",
+		},
+		{
+			S:    "PT0S",
+			Want: "0 seconds",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := p.Humanize(); s != testCase.Want {
+			t.Errorf("%s: description is %q but should be %q", testCase.S, s, testCase.Want)
+		}
+	}
+}
+
+func TestZeroRoundTrip(t *testing.T) {
+	testTable := []string{
+		"PT0S",
+		"R/PT0S",
+		"R3/PT0S",
+		"P0D",
+		"P0Y0M0DT0H0M0S",
+	}
+
+	for _, s := range testTable {
+		p, err := isoperiod.Parse(s)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !p.IsZero() {
+			t.Errorf("%s: period is not zero", s)
+		}
+
+		str := p.String()
+		q, err := isoperiod.Parse(str)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !q.IsZero() || !q.Equal(p) {
+			t.Errorf("%s: round-trip is %s but should be %s", s, q, p)
+		}
+
+		if str2 := q.String(); str2 != str {
+			t.Errorf("%s: string is %s but should be %s", s, str2, str)
+		}
+	}
+
+	if s := isoperiod.New(now).String(); s != "PT0S" {
+		t.Errorf("zero period is %s but should be PT0S", s)
+	}
+}
+
+func TestIsCanonical(t *testing.T) {
+	testTable := []struct {
+		S         string
+		Canonical bool
+	}{
+		{
+			S:         "P1M",
+			Canonical: true,
+		},
+		{
+			S:         "R/-PT1.5S",
+			Canonical: true,
+		},
+		{
+			S:         "PT90M",
+			Canonical: true,
+		},
+		{
+			S:         "PT0S",
+			Canonical: true,
+		},
+		{
+			S:         "P01M",
+			Canonical: false,
+		},
+		{
+			S:         "P0Y1M",
+			Canonical: false,
+		},
+		{
+			S:         "R0/P1M",
+			Canonical: false,
+		},
+		{
+			S:         "PT0,5S",
+			Canonical: false,
+		},
+		{
+			S:         "PT1.50S",
+			Canonical: false,
+		},
+		{
+			S:         "P1MT",
+			Canonical: false,
+		},
+		{
+			S:         "",
+			Canonical: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		if c := isoperiod.IsCanonical(testCase.S); c != testCase.Canonical {
+			t.Errorf("%q: canonical is %t but should be %t", testCase.S, c, testCase.Canonical)
+		}
+	}
+}
+
+func TestComponents(t *testing.T) {
+	testTable := []struct {
+		S          string
+		Components []string
+	}{
+		{
+			S:          "P1Y2MT3M",
+			Components: []string{"year", "month", "minute"},
+		},
+		{
+			S:          "R/-P2WT1.5S",
+			Components: []string{"week", "second", "nanosecond"},
+		},
+		{
+			S:          "PT0S",
+			Components: []string{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		c := p.Components()
+		if c == nil || !reflect.DeepEqual(c, testCase.Components) {
+			t.Errorf("%s: components are %#v but should be %#v", testCase.S, c, testCase.Components)
+		}
+	}
+}
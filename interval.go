@@ -0,0 +1,198 @@
+package isoperiod
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampLayouts are the layouts tried by ParseInterval, in order.
+var timestampLayouts = []string{
+	time.RFC3339,           // extended format: 2007-03-01T13:00:00Z
+	"20060102T150405Z0700", // basic format: 20070301T130000Z
+	"20060102T1504Z0700",   // basic format without seconds: 20070301T1300Z
+}
+
+// parseTimestamp parses s using the first matching layout of timestampLayouts.
+func parseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+// An Interval represents an ISO 8601 time interval.
+//
+// Period is only set if the interval was given with a duration,
+// Start and End are always calculated.
+type Interval struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Period *Period   `json:"period,omitempty"`
+}
+
+// ParseInterval converts an ISO 8601 interval string to an Interval.
+//
+// The following formats are supported:
+// - <start>/<end>
+// - <start>/<duration>
+// - <duration>/<end>
+//
+// Examples would be:
+// - 2007-03-01T13:00:00Z/2008-05-11T15:30:00Z
+// - 2007-03-01T13:00:00Z/P1Y2M10DT2H30M
+// - P1Y2M10DT2H30M/2008-05-11T15:30:00Z
+//
+// Timestamps can be given in the extended format (RFC 3339, 2007-03-01T13:00:00Z)
+// or the basic format (20070301T130000Z, seconds can be left out).
+// The layouts are tried in this order. Intervals ending before they start are rejected.
+func ParseInterval(s string) (*Interval, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid interval format")
+	}
+
+	var (
+		result = &Interval{}
+		err    error
+	)
+
+	startIsPeriod := isPeriod(parts[0])
+	endIsPeriod := isPeriod(parts[1])
+
+	switch {
+	case startIsPeriod && endIsPeriod:
+		return nil, errors.New("interval can't consist of two durations")
+	case startIsPeriod:
+		result.Period, err = Parse(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		result.End, err = parseTimestamp(parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		result.Start = result.Period.Sub(result.End)
+	case endIsPeriod:
+		result.Start, err = parseTimestamp(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		result.Period, err = Parse(parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		result.End = result.Period.Add(result.Start)
+	default:
+		result.Start, err = parseTimestamp(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		result.End, err = parseTimestamp(parts[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if result.End.Before(result.Start) {
+		return nil, errors.New("interval ends before it starts")
+	}
+
+	return result, nil
+}
+
+// isPeriod reports whether the part of an interval is a duration rather than a timestamp,
+// following the rules of Parse: an optional sign and a case-insensitive P designator.
+func isPeriod(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+
+	return strings.HasPrefix(s, "P") || strings.HasPrefix(s, "p")
+}
+
+// ParseRepeatingInterval converts an ISO 8601 repeating interval like
+// R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M to its repetitions and the interval.
+//
+// The interval can have any of the formats supported by ParseInterval.
+// Like in Parse, the R designator is case-insensitive and the repetitions are digits only.
+// "R" without a number results in Endless repetitions. If the interval was given
+// with a duration, the repetitions are also set on its Period.
+func ParseRepeatingInterval(s string) (int, *Interval, error) {
+	head, rest, ok := strings.Cut(s, "/")
+	if !ok || (!strings.HasPrefix(head, "R") && !strings.HasPrefix(head, "r")) {
+		return 0, nil, errors.New("invalid repeating interval format")
+	}
+
+	repetitions := Endless
+	if digits := head[1:]; digits != "" {
+		// Like Parse, only digits are accepted: no sign, no spaces.
+		if strings.TrimLeft(digits, "0123456789") != "" {
+			return 0, nil, errors.New("invalid repeating interval format")
+		}
+
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return 0, nil, errors.New("invalid repeating interval format")
+		}
+
+		repetitions = n
+	}
+
+	iv, err := ParseInterval(rest)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if iv.Period != nil {
+		iv.Period.Repetitions = repetitions
+	}
+
+	return repetitions, iv, nil
+}
+
+// Contains reports whether t lies within the interval. The interval is half-open:
+// the start is included, the end is not, so consecutive intervals don't share an instant.
+//
+// If the interval was built with a Period but without Start or End, the missing
+// time is calculated from the Period.
+func (iv *Interval) Contains(t time.Time) bool {
+	start, end := iv.bounds()
+
+	return !t.Before(start) && t.Before(end)
+}
+
+// bounds returns the start and end of the interval, calculating a missing one from the Period.
+func (iv *Interval) bounds() (start, end time.Time) {
+	start, end = iv.Start, iv.End
+	if iv.Period == nil {
+		return start, end
+	}
+
+	switch {
+	case end.IsZero() && !start.IsZero():
+		end = iv.Period.Add(start)
+	case start.IsZero() && !end.IsZero():
+		start = iv.Period.Sub(end)
+	}
+
+	return start, end
+}
+
+// Overlaps reports whether a and b share at least one instant. Like Contains,
+// intervals are half-open, so touching intervals (one ending when the other starts)
+// don't overlap.
+func (a *Interval) Overlaps(b *Interval) bool {
+	aStart, aEnd := a.bounds()
+	bStart, bEnd := b.bounds()
+
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
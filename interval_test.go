@@ -0,0 +1,351 @@
+package isoperiod_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestParseInterval(t *testing.T) {
+	testTable := []struct {
+		S      string
+		Start  string
+		End    string
+		Period bool
+		Err    error
+	}{
+		{
+			S:     "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z",
+			Start: "2007-03-01T13:00:00Z",
+			End:   "2008-05-11T15:30:00Z",
+			Err:   nil,
+		},
+		{
+			S:      "2007-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			Start:  "2007-03-01T13:00:00Z",
+			End:    "2008-05-11T15:30:00Z",
+			Period: true,
+			Err:    nil,
+		},
+		{
+			S:      "P1Y2M10DT2H30M/2008-05-11T15:30:00Z",
+			Start:  "2007-03-01T13:00:00Z",
+			End:    "2008-05-11T15:30:00Z",
+			Period: true,
+			Err:    nil,
+		},
+		{
+			S:      "p1y2m10dt2h30m/2008-05-11T15:30:00Z",
+			Start:  "2007-03-01T13:00:00Z",
+			End:    "2008-05-11T15:30:00Z",
+			Period: true,
+			Err:    nil,
+		},
+		{
+			S:   "P1D/P2D",
+			Err: errors.New("interval can't consist of two durations"),
+		},
+		{
+			S:   "2008-05-11T15:30:00Z/2007-03-01T13:00:00Z",
+			Err: errors.New("interval ends before it starts"),
+		},
+		{
+			S:   "-P1D/2008-05-11T15:30:00Z",
+			Err: errors.New("interval ends before it starts"),
+		},
+		{
+			S:   "2008-05-11T15:30:00Z/-PT1H",
+			Err: errors.New("interval ends before it starts"),
+		},
+		{
+			S:   "2007-03-01T13:00:00Z",
+			Err: errors.New("invalid interval format"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		iv, err := isoperiod.ParseInterval(testCase.S)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		start, _ := time.Parse(time.RFC3339, testCase.Start)
+		end, _ := time.Parse(time.RFC3339, testCase.End)
+
+		if !iv.Start.Equal(start) {
+			t.Errorf("%s: start is %s but should be %s", testCase.S, iv.Start, start)
+		}
+
+		if !iv.End.Equal(end) {
+			t.Errorf("%s: end is %s but should be %s", testCase.S, iv.End, end)
+		}
+
+		if !testCase.Period && iv.Period != nil {
+			t.Errorf("%s: period is %s but should be nil", testCase.S, iv.Period)
+		}
+
+		if testCase.Period && (iv.Period == nil || !iv.Period.Add(start).Equal(end)) {
+			t.Errorf("%s: period %v doesn't span the interval", testCase.S, iv.Period)
+		}
+	}
+
+	if _, err := isoperiod.ParseInterval("yesterday/P1D"); err == nil {
+		t.Error("error is nil but should be set")
+	}
+}
+
+func TestParseIntervalBasicFormat(t *testing.T) {
+	testTable := []struct {
+		Extended string
+		Basic    string
+	}{
+		{
+			Extended: "2007-03-01T13:00:00Z/2008-05-11T15:30:00Z",
+			Basic:    "20070301T130000Z/20080511T153000Z",
+		},
+		{
+			Extended: "2007-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			Basic:    "20070301T1300Z/P1Y2M10DT2H30M",
+		},
+		{
+			Extended: "P1Y2M10DT2H30M/2008-05-11T17:30:00+02:00",
+			Basic:    "P1Y2M10DT2H30M/20080511T173000+0200",
+		},
+		{
+			Extended: "2007-03-01T13:00:00.5Z/PT1H",
+			Basic:    "20070301T130000.5Z/PT1H",
+		},
+	}
+
+	for _, testCase := range testTable {
+		extended, err := isoperiod.ParseInterval(testCase.Extended)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		basic, err := isoperiod.ParseInterval(testCase.Basic)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !basic.Start.Equal(extended.Start) {
+			t.Errorf("%s: start is %s but should be %s", testCase.Basic, basic.Start, extended.Start)
+		}
+
+		if !basic.End.Equal(extended.End) {
+			t.Errorf("%s: end is %s but should be %s", testCase.Basic, basic.End, extended.End)
+		}
+
+		if (basic.Period == nil) != (extended.Period == nil) || (basic.Period != nil && !basic.Period.Equal(extended.Period)) {
+			t.Errorf("%s: period is %v but should be %v", testCase.Basic, basic.Period, extended.Period)
+		}
+	}
+
+	if _, err := isoperiod.ParseInterval("2007-03-01/P1D"); err == nil {
+		t.Error("error is nil but should be set")
+	}
+}
+
+func TestParseRepeatingInterval(t *testing.T) {
+	testTable := []struct {
+		S           string
+		Repetitions int
+		Start       string
+		End         string
+		Err         error
+	}{
+		{
+			S:           "R5/2008-03-01T13:00:00Z/P1Y2M10DT2H30M",
+			Repetitions: 5,
+			Start:       "2008-03-01T13:00:00Z",
+			End:         "2009-05-11T15:30:00Z",
+		},
+		{
+			S:           "R/2008-03-01T13:00:00Z/PT1H",
+			Repetitions: isoperiod.Endless,
+			Start:       "2008-03-01T13:00:00Z",
+			End:         "2008-03-01T14:00:00Z",
+		},
+		{
+			S:           "R3/2008-03-01T13:00:00Z/2008-03-02T13:00:00Z",
+			Repetitions: 3,
+			Start:       "2008-03-01T13:00:00Z",
+			End:         "2008-03-02T13:00:00Z",
+		},
+		{
+			S:           "R3/P1D/2008-03-02T13:00:00Z",
+			Repetitions: 3,
+			Start:       "2008-03-01T13:00:00Z",
+			End:         "2008-03-02T13:00:00Z",
+		},
+		{
+			S:   "2008-03-01T13:00:00Z/PT1H",
+			Err: errors.New("invalid repeating interval format"),
+		},
+		{
+			S:           "r5/2008-03-01T13:00:00Z/p1d",
+			Repetitions: 5,
+			Start:       "2008-03-01T13:00:00Z",
+			End:         "2008-03-02T13:00:00Z",
+		},
+		{
+			S:   "Rx/2008-03-01T13:00:00Z/PT1H",
+			Err: errors.New("invalid repeating interval format"),
+		},
+		{
+			S:   "R+3/2008-03-01T13:00:00Z/PT1H",
+			Err: errors.New("invalid repeating interval format"),
+		},
+		{
+			S:   "R-3/2008-03-01T13:00:00Z/PT1H",
+			Err: errors.New("invalid repeating interval format"),
+		},
+		{
+			S:   "R5/2008-03-01T13:00:00Z",
+			Err: errors.New("invalid interval format"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		repetitions, iv, err := isoperiod.ParseRepeatingInterval(testCase.S)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		if repetitions != testCase.Repetitions {
+			t.Errorf("%s: repetitions are %d but should be %d", testCase.S, repetitions, testCase.Repetitions)
+		}
+
+		start, _ := time.Parse(time.RFC3339, testCase.Start)
+		end, _ := time.Parse(time.RFC3339, testCase.End)
+
+		if !iv.Start.Equal(start) {
+			t.Errorf("%s: start is %s but should be %s", testCase.S, iv.Start, start)
+		}
+
+		if !iv.End.Equal(end) {
+			t.Errorf("%s: end is %s but should be %s", testCase.S, iv.End, end)
+		}
+
+		if iv.Period != nil && iv.Period.Repetitions != testCase.Repetitions {
+			t.Errorf("%s: period repetitions are %d but should be %d", testCase.S, iv.Period.Repetitions, testCase.Repetitions)
+		}
+	}
+}
+
+func TestIntervalContains(t *testing.T) {
+	iv, err := isoperiod.ParseInterval("2023-01-01T22:00:00Z/PT2H")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	window, _ := isoperiod.Parse("PT2H")
+	start, _ := time.Parse(time.RFC3339, "2023-01-01T22:00:00Z")
+
+	testTable := []struct {
+		T        string
+		Contains bool
+	}{
+		{
+			T:        "2023-01-01T21:59:59Z",
+			Contains: false,
+		},
+		{
+			T:        "2023-01-01T22:00:00Z",
+			Contains: true,
+		},
+		{
+			T:        "2023-01-01T23:30:00Z",
+			Contains: true,
+		},
+		{
+			T:        "2023-01-02T00:00:00Z",
+			Contains: false,
+		},
+		{
+			T:        "2023-01-02T01:00:00Z",
+			Contains: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		ts, _ := time.Parse(time.RFC3339, testCase.T)
+
+		if c := iv.Contains(ts); c != testCase.Contains {
+			t.Errorf("%s: Contains is %t but should be %t", testCase.T, c, testCase.Contains)
+		}
+
+		// Without End, the period is expanded.
+		partial := &isoperiod.Interval{Start: start, Period: window}
+		if c := partial.Contains(ts); c != testCase.Contains {
+			t.Errorf("%s (start and period): Contains is %t but should be %t", testCase.T, c, testCase.Contains)
+		}
+	}
+}
+
+func TestIntervalOverlaps(t *testing.T) {
+	testTable := []struct {
+		A        string
+		B        string
+		Overlaps bool
+	}{
+		{
+			A:        "2023-01-01T22:00:00Z/PT2H",
+			B:        "2023-01-01T23:00:00Z/PT2H",
+			Overlaps: true,
+		},
+		{
+			A:        "2023-01-01T22:00:00Z/PT2H",
+			B:        "2023-01-02T00:00:00Z/PT2H",
+			Overlaps: false,
+		},
+		{
+			A:        "2023-01-01T00:00:00Z/P1D",
+			B:        "PT1H/2023-01-01T12:00:00Z",
+			Overlaps: true,
+		},
+		{
+			A:        "2023-01-01T00:00:00Z/2023-01-01T06:00:00Z",
+			B:        "2023-02-01T00:00:00Z/P1M",
+			Overlaps: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		a, err := isoperiod.ParseInterval(testCase.A)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		b, err := isoperiod.ParseInterval(testCase.B)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if o := a.Overlaps(b); o != testCase.Overlaps {
+			t.Errorf("%s and %s: Overlaps is %t but should be %t", testCase.A, testCase.B, o, testCase.Overlaps)
+		}
+
+		if o := b.Overlaps(a); o != testCase.Overlaps {
+			t.Errorf("%s and %s: Overlaps is %t but should be %t", testCase.B, testCase.A, o, testCase.Overlaps)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package isoperiod
+
+import (
+	"sync"
+	"time"
+)
+
+// A Limiter allows at most one event per period, e.g. PT1M allows one event per minute.
+// It is safe for concurrent use.
+type Limiter struct {
+	period *Period
+	mu     sync.Mutex
+	next   time.Time
+}
+
+// Limiter returns a new limiter based on a copy of the period.
+// The repetitions are ignored.
+func (r *Period) Limiter() *Limiter {
+	return &Limiter{period: r.Clone()}
+}
+
+// Allow reports whether an event may happen at now. If so, further events are denied
+// until one period after now. Periods without length allow every event.
+func (l *Limiter) Allow(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.next) {
+		return false
+	}
+
+	l.next = l.period.Add(now)
+
+	return true
+}
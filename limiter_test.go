@@ -0,0 +1,89 @@
+package isoperiod_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestLimiter(t *testing.T) {
+	p, err := isoperiod.Parse("PT1M")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := p.Limiter()
+
+	testTable := []struct {
+		Offset time.Duration
+		Allow  bool
+	}{
+		{
+			Offset: 0,
+			Allow:  true,
+		},
+		{
+			Offset: 30 * time.Second,
+			Allow:  false,
+		},
+		{
+			Offset: 59 * time.Second,
+			Allow:  false,
+		},
+		{
+			Offset: time.Minute,
+			Allow:  true,
+		},
+		{
+			Offset: 90 * time.Second,
+			Allow:  false,
+		},
+		{
+			Offset: 5 * time.Minute,
+			Allow:  true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		if allow := l.Allow(now.Add(testCase.Offset)); allow != testCase.Allow {
+			t.Errorf("%s: allow is %t but should be %t", testCase.Offset, allow, testCase.Allow)
+		}
+	}
+
+	// Changing the period afterwards doesn't affect the limiter.
+	p.Minute = 0
+	if l.Allow(now.Add(5*time.Minute + time.Second)) {
+		t.Error("allow is true but should be false")
+	}
+}
+
+func TestLimiterConcurrent(t *testing.T) {
+	p, err := isoperiod.Parse("PT1H")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := p.Limiter()
+
+	var (
+		wg      sync.WaitGroup
+		allowed int32
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.Allow(now) {
+				atomic.AddInt32(&allowed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 1 {
+		t.Errorf("allowed %d events but should be 1", allowed)
+	}
+}
@@ -0,0 +1,220 @@
+package isoperiod
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Occurrences returns an iterator over the times the period occurs, beginning with start.
+//
+// It yields start, start+period, start+2*period and so on, "Repetitions" times in total.
+// Endless periods yield until the consumer stops, periods without repetitions yield nothing.
+// With Go 1.23 or newer the iterator can be used in a range loop:
+//
+//	for t := range p.Occurrences(start) {
+//		...
+//	}
+//
+// Every occurrence has to be after the previous one, otherwise the iterator stops.
+// Periods without length and negative periods therefore only yield start. The same goes
+// for signed components (see ModeExtended) that don't always move forward: P1M-30D steps
+// from January 31 to February 1, then back to January 30, and would never pass a later time.
+// All functions stepping through occurrences follow this rule.
+func (r *Period) Occurrences(start time.Time) func(yield func(time.Time) bool) {
+	return func(yield func(time.Time) bool) {
+		t := start
+		for i := 0; r.Repetitions == Endless || i < r.Repetitions; i++ {
+			if !yield(t) {
+				return
+			}
+
+			next, ok := r.step(t, false)
+			if !ok {
+				return
+			}
+
+			t = next
+		}
+	}
+}
+
+// step moves t by one period, forward with Add or back with Sub, and reports
+// whether t actually moved in that direction (see Occurrences).
+func (r *Period) step(t time.Time, back bool) (time.Time, bool) {
+	if back {
+		prev := r.Sub(t)
+		return prev, prev.Before(t)
+	}
+
+	next := r.Add(t)
+	return next, next.After(t)
+}
+
+// Times returns all occurrence times of a finite period, beginning with start.
+//
+// Endless periods return nil instead of allocating forever,
+// as do periods without repetitions.
+func (r *Period) Times(start time.Time) []time.Time {
+	if r.Repetitions <= 0 {
+		return nil
+	}
+
+	result := make([]time.Time, 0, r.Repetitions)
+	r.Occurrences(start)(func(t time.Time) bool {
+		result = append(result, t)
+		return true
+	})
+
+	return result
+}
+
+// ToIntervals returns one interval per occurrence of a finite period, beginning with start.
+// Each interval spans from its occurrence to the next one, so R3/P1D results in three
+// consecutive days. The Period of each interval is a copy of r without repetitions.
+//
+// Like Times, endless periods and periods without repetitions return nil. The intervals
+// stop at the first occurrence whose next one isn't after it (see Occurrences),
+// so no interval ends before it starts.
+func (r *Period) ToIntervals(start time.Time) []*Interval {
+	times := r.Times(start)
+	if times == nil {
+		return nil
+	}
+
+	var result []*Interval
+	for _, t := range times {
+		end, ok := r.step(t, false)
+		if !ok {
+			break
+		}
+
+		p := r.Clone()
+		p.Repetitions = 0
+
+		result = append(result, &Interval{Start: t, End: end, Period: p})
+	}
+
+	return result
+}
+
+// Bounds select whether the start and end of a range are included.
+type Bounds int
+
+const (
+	// Closed includes both start and end: [start, end].
+	Closed Bounds = iota
+
+	// ClosedOpen includes start, but not end: [start, end).
+	ClosedOpen
+
+	// OpenClosed includes end, but not start: (start, end].
+	OpenClosed
+
+	// Open excludes both start and end: (start, end).
+	Open
+)
+
+// String returns the interval notation of the bounds, e.g. "[)" for ClosedOpen.
+func (b Bounds) String() string {
+	switch b {
+	case Closed:
+		return "[]"
+	case ClosedOpen:
+		return "[)"
+	case OpenClosed:
+		return "(]"
+	case Open:
+		return "()"
+	default:
+		return fmt.Sprintf("Bounds(%d)", int(b))
+	}
+}
+
+// afterStart reports whether t is past start with respect to the bounds.
+func (b Bounds) afterStart(t, start time.Time) bool {
+	if b == OpenClosed || b == Open {
+		return t.After(start)
+	}
+
+	return !t.Before(start)
+}
+
+// beforeEnd reports whether t is before end with respect to the bounds.
+func (b Bounds) beforeEnd(t, end time.Time) bool {
+	if b == ClosedOpen || b == Open {
+		return t.Before(end)
+	}
+
+	return !t.After(end)
+}
+
+// OccurrencesBetween returns an iterator over the occurrences beginning with start
+// (see Occurrences) that lie within start and end. The bounds select whether
+// start and end themselves are included.
+//
+// An excluded start still counts as one of the "Repetitions": R3/PT1H with Open bounds
+// yields at most two times. Periods without length (or negative ones) yield at most start itself,
+// since they would never pass end.
+func (r *Period) OccurrencesBetween(start, end time.Time, bounds Bounds) func(yield func(time.Time) bool) {
+	return func(yield func(time.Time) bool) {
+		r.Occurrences(start)(func(t time.Time) bool {
+			if !bounds.beforeEnd(t, end) {
+				return false
+			}
+
+			if !bounds.afterStart(t, start) {
+				return true
+			}
+
+			return yield(t)
+		})
+	}
+}
+
+// CountBetween returns the number of occurrences beginning with start that lie within
+// start and end. By default both are inclusive (Closed): PT1H occurs 4 times from 10:00 to 13:30,
+// at 10:00, 11:00, 12:00 and 13:00. Other bounds can be given, see OccurrencesBetween.
+//
+// Finite periods count at most "Repetitions" occurrences, periods without repetitions
+// count none. Periods without length (or negative ones) only count start itself,
+// since they would never pass end.
+func (r *Period) CountBetween(start, end time.Time, bounds ...Bounds) int {
+	b := Closed
+	if len(bounds) > 0 {
+		b = bounds[0]
+	}
+
+	n := 0
+	r.OccurrencesBetween(start, end, b)(func(time.Time) bool {
+		n++
+		return true
+	})
+
+	return n
+}
+
+// debugLimit caps the occurrences written by DebugSchedule.
+const debugLimit = 1000
+
+// DebugSchedule writes the next n occurrences beginning with start (see Occurrences)
+// to w, one RFC 3339 time per line. Finite periods write at most "Repetitions" lines.
+// n is capped at 1000, so a typo can't flood the output for endless periods.
+// Write errors are ignored, it's meant for debugging only.
+func (r *Period) DebugSchedule(start time.Time, n int, w io.Writer) {
+	if n > debugLimit {
+		n = debugLimit
+	}
+
+	i := 0
+	r.Occurrences(start)(func(t time.Time) bool {
+		if i >= n {
+			return false
+		}
+		i++
+
+		fmt.Fprintln(w, t.Format(time.RFC3339))
+
+		return true
+	})
+}
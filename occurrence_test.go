@@ -0,0 +1,407 @@
+package isoperiod_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func collect(seq func(yield func(time.Time) bool), limit int) []time.Time {
+	var result []time.Time
+
+	seq(func(t time.Time) bool {
+		result = append(result, t)
+		return len(result) < limit
+	})
+
+	return result
+}
+
+func TestOccurrences(t *testing.T) {
+	testTable := []struct {
+		S     string
+		Limit int
+		Want  []time.Time
+	}{
+		{
+			S:     "R5/P1D",
+			Limit: 10,
+			Want:  []time.Time{now, calcTime(0, 0, 1, 0, 0, 0), calcTime(0, 0, 2, 0, 0, 0), calcTime(0, 0, 3, 0, 0, 0), calcTime(0, 0, 4, 0, 0, 0)},
+		},
+		{
+			S:     "R/PT1H",
+			Limit: 3,
+			Want:  []time.Time{now, calcTime(0, 0, 0, 1, 0, 0), calcTime(0, 0, 0, 2, 0, 0)},
+		},
+		{
+			S:     "P1D",
+			Limit: 10,
+			Want:  nil,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		got := collect(p.Occurrences(now), testCase.Limit)
+		if len(got) != len(testCase.Want) {
+			t.Errorf("%s: got %d occurrences but should be %d", testCase.S, len(got), len(testCase.Want))
+			continue
+		}
+
+		for i := range got {
+			if !got[i].Equal(testCase.Want[i]) {
+				t.Errorf("%s: occurrence %d is %s but should be %s", testCase.S, i, got[i], testCase.Want[i])
+			}
+		}
+	}
+}
+
+func TestOccurrencesForward(t *testing.T) {
+	jan31, _ := time.Parse(time.RFC3339, "2023-01-31T00:00:00Z")
+	feb1 := jan31.AddDate(0, 0, 1)
+
+	testTable := []struct {
+		S    string
+		Want []time.Time
+	}{
+		{
+			// Steps back to January 30 after February 1.
+			S:    "R5/P1M-30D",
+			Want: []time.Time{jan31, feb1},
+		},
+		{
+			S:    "R3/PT0S",
+			Want: []time.Time{jan31},
+		},
+		{
+			S:    "R3/-P1D",
+			Want: []time.Time{jan31},
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.ParseWithMode(testCase.S, isoperiod.ModeExtended)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		got := p.Times(jan31)
+		if len(got) != len(testCase.Want) {
+			t.Errorf("%s: got %d times but should be %d", testCase.S, len(got), len(testCase.Want))
+			continue
+		}
+
+		for i := range got {
+			if !got[i].Equal(testCase.Want[i]) {
+				t.Errorf("%s: time %d is %s but should be %s", testCase.S, i, got[i], testCase.Want[i])
+			}
+		}
+
+		var b bytes.Buffer
+		p.DebugSchedule(jan31, 10, &b)
+		if n := strings.Count(b.String(), "\n"); n != len(testCase.Want) {
+			t.Errorf("%s: debug schedule has %d lines but should be %d", testCase.S, n, len(testCase.Want))
+		}
+	}
+
+	p, _ := isoperiod.ParseWithMode("R/P1M-30D", isoperiod.ModeExtended)
+	if next := p.Next(feb1); !next.IsZero() {
+		t.Errorf("next is %s but should be zero", next)
+	}
+
+	if next := p.NextOnWeekdays(feb1, []time.Weekday{time.Monday}); !next.IsZero() {
+		t.Errorf("next weekday is %s but should be zero", next)
+	}
+}
+
+func TestTimes(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Want []time.Time
+	}{
+		{
+			S:    "R3/PT30M",
+			Want: []time.Time{now, calcTime(0, 0, 0, 0, 30, 0), calcTime(0, 0, 0, 1, 0, 0)},
+		},
+		{
+			S:    "R1/P1Y",
+			Want: []time.Time{now},
+		},
+		{
+			S:    "R/P1D",
+			Want: nil,
+		},
+		{
+			S:    "P1D",
+			Want: nil,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		got := p.Times(now)
+		if len(got) != len(testCase.Want) {
+			t.Errorf("%s: got %d times but should be %d", testCase.S, len(got), len(testCase.Want))
+			continue
+		}
+
+		for i := range got {
+			if !got[i].Equal(testCase.Want[i]) {
+				t.Errorf("%s: time %d is %s but should be %s", testCase.S, i, got[i], testCase.Want[i])
+			}
+		}
+	}
+}
+
+func TestToIntervals(t *testing.T) {
+	start, _ := time.Parse(time.RFC3339, "2023-03-01T00:00:00Z")
+
+	p, err := isoperiod.Parse("R3/P1D")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	intervals := p.ToIntervals(start)
+	if len(intervals) != 3 {
+		t.Fatalf("got %d intervals but should be 3", len(intervals))
+	}
+
+	for i, iv := range intervals {
+		wantStart := start.AddDate(0, 0, i)
+		wantEnd := start.AddDate(0, 0, i+1)
+
+		if !iv.Start.Equal(wantStart) || !iv.End.Equal(wantEnd) {
+			t.Errorf("interval %d is %s/%s but should be %s/%s", i, iv.Start, iv.End, wantStart, wantEnd)
+		}
+
+		if s := iv.Period.String(); s != "P1D" {
+			t.Errorf("interval %d: period is %s but should be P1D", i, s)
+		}
+	}
+
+	if !intervals[0].End.Equal(intervals[1].Start) || intervals[0].Overlaps(intervals[1]) {
+		t.Error("consecutive intervals should touch without overlapping")
+	}
+
+	for _, s := range []string{"R/P1D", "P1D", "R3/PT0S"} {
+		p, _ := isoperiod.Parse(s)
+		if intervals := p.ToIntervals(start); intervals != nil {
+			t.Errorf("%s: got %d intervals but should be nil", s, len(intervals))
+		}
+	}
+
+	// P1M-30D steps from January 31 to February 1, then back to January 30.
+	p, err = isoperiod.ParseWithMode("R3/P1M-30D", isoperiod.ModeExtended)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jan31, _ := time.Parse(time.RFC3339, "2023-01-31T00:00:00Z")
+	intervals = p.ToIntervals(jan31)
+	if len(intervals) != 1 {
+		t.Fatalf("got %d intervals but should be 1", len(intervals))
+	}
+
+	if want := jan31.AddDate(0, 0, 1); !intervals[0].Start.Equal(jan31) || !intervals[0].End.Equal(want) {
+		t.Errorf("interval is %s/%s but should be %s/%s", intervals[0].Start, intervals[0].End, jan31, want)
+	}
+}
+
+func TestCountBetween(t *testing.T) {
+	start := calcTime(0, 0, 0, 10, 0, 0)
+
+	testTable := []struct {
+		S     string
+		End   time.Time
+		Count int
+	}{
+		{
+			S:     "R/PT1H",
+			End:   start.Add(210 * time.Minute),
+			Count: 4,
+		},
+		{
+			S:     "R/PT1H",
+			End:   start.Add(3 * time.Hour),
+			Count: 4,
+		},
+		{
+			S:     "R/PT1H",
+			End:   start,
+			Count: 1,
+		},
+		{
+			S:     "R/PT1H",
+			End:   start.Add(-time.Minute),
+			Count: 0,
+		},
+		{
+			S:     "R2/PT1H",
+			End:   start.Add(210 * time.Minute),
+			Count: 2,
+		},
+		{
+			S:     "PT1H",
+			End:   start.Add(210 * time.Minute),
+			Count: 0,
+		},
+		{
+			S:     "R/P1M",
+			End:   calcTime(1, 0, 0, 0, 0, 0),
+			Count: 12,
+		},
+		{
+			S:     "R/-PT1H",
+			End:   start.Add(210 * time.Minute),
+			Count: 1,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if n := p.CountBetween(start, testCase.End); n != testCase.Count {
+			t.Errorf("%s: count is %d but should be %d", testCase.S, n, testCase.Count)
+		}
+	}
+}
+
+func TestCountBetweenBounds(t *testing.T) {
+	start := calcTime(0, 0, 0, 10, 0, 0)
+
+	testTable := []struct {
+		S      string
+		End    time.Time
+		Counts map[isoperiod.Bounds]int
+	}{
+		{
+			S:      "R/PT1H",
+			End:    start.Add(3 * time.Hour),
+			Counts: map[isoperiod.Bounds]int{isoperiod.Closed: 4, isoperiod.ClosedOpen: 3, isoperiod.OpenClosed: 3, isoperiod.Open: 2},
+		},
+		{
+			S:      "R/PT1H",
+			End:    start.Add(210 * time.Minute),
+			Counts: map[isoperiod.Bounds]int{isoperiod.Closed: 4, isoperiod.ClosedOpen: 4, isoperiod.OpenClosed: 3, isoperiod.Open: 3},
+		},
+		{
+			S:      "R3/PT1H",
+			End:    start.Add(3 * time.Hour),
+			Counts: map[isoperiod.Bounds]int{isoperiod.Closed: 3, isoperiod.ClosedOpen: 3, isoperiod.OpenClosed: 2, isoperiod.Open: 2},
+		},
+		{
+			S:      "R/PT1H",
+			End:    start,
+			Counts: map[isoperiod.Bounds]int{isoperiod.Closed: 1, isoperiod.ClosedOpen: 0, isoperiod.OpenClosed: 0, isoperiod.Open: 0},
+		},
+		{
+			S:      "R/PT0S",
+			End:    start.Add(time.Hour),
+			Counts: map[isoperiod.Bounds]int{isoperiod.Closed: 1, isoperiod.ClosedOpen: 1, isoperiod.OpenClosed: 0, isoperiod.Open: 0},
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		for bounds, want := range testCase.Counts {
+			if n := p.CountBetween(start, testCase.End, bounds); n != want {
+				t.Errorf("%s %s: count is %d but should be %d", testCase.S, bounds, n, want)
+			}
+
+			if n := len(collect(p.OccurrencesBetween(start, testCase.End, bounds), 10)); n != want {
+				t.Errorf("%s %s: yielded %d times but should be %d", testCase.S, bounds, n, want)
+			}
+		}
+	}
+}
+
+func TestCountBetweenSigned(t *testing.T) {
+	// P1M-30D steps from January 31 to February 1, then back to January 30.
+	p, err := isoperiod.ParseWithMode("R/P1M-30D", isoperiod.ModeExtended)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start, _ := time.Parse(time.RFC3339, "2023-01-31T00:00:00Z")
+	end, _ := time.Parse(time.RFC3339, "2023-12-31T00:00:00Z")
+
+	if n := p.CountBetween(start, end); n != 2 {
+		t.Errorf("count is %d but should be 2", n)
+	}
+}
+
+func TestDebugSchedule(t *testing.T) {
+	testTable := []struct {
+		S    string
+		N    int
+		Want string
+	}{
+		{
+			S:    "R/PT30M",
+			N:    3,
+			Want: "2023-01-01T00:00:00Z\n2023-01-01T00:30:00Z\n2023-01-01T01:00:00Z\n",
+		},
+		{
+			S:    "R2/P1D",
+			N:    5,
+			Want: "2023-01-01T00:00:00Z\n2023-01-02T00:00:00Z\n",
+		},
+		{
+			S:    "P1D",
+			N:    5,
+			Want: "",
+		},
+		{
+			S:    "R/PT1H",
+			N:    0,
+			Want: "",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		var buf bytes.Buffer
+		p.DebugSchedule(now, testCase.N, &buf)
+
+		if buf.String() != testCase.Want {
+			t.Errorf("%s: output is %q but should be %q", testCase.S, buf.String(), testCase.Want)
+		}
+	}
+
+	p, _ := isoperiod.Parse("R/PT1S")
+
+	var buf bytes.Buffer
+	p.DebugSchedule(now, 1000000, &buf)
+
+	if n := strings.Count(buf.String(), "\n"); n != 1000 {
+		t.Errorf("wrote %d lines but should be capped at 1000", n)
+	}
+}
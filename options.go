@@ -0,0 +1,67 @@
+package isoperiod
+
+// An Option configures a Period created with New.
+type Option func(*Period)
+
+// WithRepetitions sets the amount of repetitions.
+func WithRepetitions(n int) Option {
+	return func(p *Period) {
+		p.Repetitions = n
+	}
+}
+
+// WithYears sets the years.
+func WithYears(n int) Option {
+	return func(p *Period) {
+		p.Year = n
+	}
+}
+
+// WithMonths sets the months.
+func WithMonths(n int) Option {
+	return func(p *Period) {
+		p.Month = n
+	}
+}
+
+// WithWeeks sets the weeks.
+func WithWeeks(n int) Option {
+	return func(p *Period) {
+		p.Week = n
+	}
+}
+
+// WithDays sets the days.
+func WithDays(n int) Option {
+	return func(p *Period) {
+		p.Day = n
+	}
+}
+
+// WithHours sets the hours.
+func WithHours(n int) Option {
+	return func(p *Period) {
+		p.Hour = n
+	}
+}
+
+// WithMinutes sets the minutes.
+func WithMinutes(n int) Option {
+	return func(p *Period) {
+		p.Minute = n
+	}
+}
+
+// WithSeconds sets the seconds.
+func WithSeconds(n int) Option {
+	return func(p *Period) {
+		p.Second = n
+	}
+}
+
+// WithNanoseconds sets the fraction of a second in nanoseconds.
+func WithNanoseconds(n int) Option {
+	return func(p *Period) {
+		p.Nanosecond = n
+	}
+}
@@ -0,0 +1,111 @@
+package isoperiod_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestNewOptions(t *testing.T) {
+	testTable := []struct {
+		Options  []isoperiod.Option
+		S        string
+		Duration time.Duration
+	}{
+		{
+			Options:  []isoperiod.Option{isoperiod.WithRepetitions(5), isoperiod.WithMinutes(30)},
+			S:        "R5/PT30M",
+			Duration: 30 * time.Minute,
+		},
+		{
+			Options:  []isoperiod.Option{isoperiod.WithRepetitions(2), isoperiod.WithYears(1), isoperiod.WithHours(2), isoperiod.WithSeconds(3)},
+			S:        "R2/P1YT2H3S",
+			Duration: 2*time.Hour + 3*time.Second,
+		},
+		{
+			Options:  []isoperiod.Option{isoperiod.WithRepetitions(1), isoperiod.WithWeeks(3)},
+			S:        "R1/P3W",
+			Duration: 0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p := isoperiod.New(now, testCase.Options...)
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("period is %s but should be %s", s, testCase.S)
+		}
+
+		if d := p.Duration(); d != testCase.Duration {
+			t.Errorf("%s: duration is %s but should be %s", p, d, testCase.Duration)
+		}
+	}
+}
+
+func TestNewFromComponents(t *testing.T) {
+	p := isoperiod.NewFromComponents(now, 20, 1, 6, 2, 2, 3, 4)
+	want := isoperiod.New(
+		now,
+		isoperiod.WithRepetitions(20),
+		isoperiod.WithYears(1),
+		isoperiod.WithMonths(6),
+		isoperiod.WithDays(2),
+		isoperiod.WithHours(2),
+		isoperiod.WithMinutes(3),
+		isoperiod.WithSeconds(4),
+	)
+
+	if p.String() != want.String() {
+		t.Errorf("period is %s but should be %s", p, want)
+	}
+
+	if p.Duration() != want.Duration() {
+		t.Errorf("duration is %s but should be %s", p.Duration(), want.Duration())
+	}
+}
+
+func TestNewRepetitions(t *testing.T) {
+	testTable := []struct {
+		Repetitions int
+		Want        int
+		S           string
+	}{
+		{
+			Repetitions: isoperiod.Endless,
+			Want:        isoperiod.Endless,
+			S:           "R/P1D",
+		},
+		{
+			Repetitions: -2,
+			Want:        0,
+			S:           "P1D",
+		},
+		{
+			Repetitions: 0,
+			Want:        0,
+			S:           "P1D",
+		},
+		{
+			Repetitions: 3,
+			Want:        3,
+			S:           "R3/P1D",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p := isoperiod.New(now, isoperiod.WithRepetitions(testCase.Repetitions), isoperiod.WithDays(1))
+
+		if p.Repetitions != testCase.Want {
+			t.Errorf("%d: repetitions are %d but should be %d", testCase.Repetitions, p.Repetitions, testCase.Want)
+		}
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("%d: period is %s but should be %s", testCase.Repetitions, s, testCase.S)
+		}
+
+		if err := p.Validate(); err != nil {
+			t.Errorf("%d: %s", testCase.Repetitions, err)
+		}
+	}
+}
@@ -1,180 +1,592 @@
 package isoperiod
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
+)
+
+// Endless is the "Repetitions" value of periods that repeat forever (R/).
+const Endless = -1
+
+// A ParseMode controls how strict ParseWithMode is.
+type ParseMode int
+
+const (
+	// ModeLax is the behavior of Parse: the string has to be a complete period
+	// with the T separator before time components, but the components can be of any size.
+	ModeLax ParseMode = iota
+
+	// ModeStrict additionally rejects out-of-range components, see Validate.
+	ModeStrict
+
+	// ModeExtended accepts a sign on individual components as permitted by ISO 8601-2,
+	// e.g. P1Y-2M for a year minus two months. The components keep their signs.
+	ModeExtended
+
+	// ModeLenient behaves like ModeLax, but tolerates a missing P designator (1Y6M).
+	// It is meant for reading malformed data from other systems.
+	ModeLenient
 )
 
 var (
-	compiler = regexp.MustCompile(`(R)?(\d+)?/?P(\d+Y)?(\d+M)?(\d+D)?(T)?(\d+H)?(\d+M)?(\d+S)?`)
+	// compiler matches the whole period string. Months and minutes share the M designator,
+	// so the minute group is only reachable after the T separator: P1M is a month, PT1M a minute.
+	// The designators are case-insensitive, which doesn't change this: pt1m is a minute, too.
+	compiler = regexp.MustCompile(`^` + pattern + `$`)
+
+	// prefix matches as much of a period as possible, to find the offset of invalid input.
+	prefix = regexp.MustCompile(`^` + pattern)
+
+	// extended and extendedPrefix are the counterparts for ModeExtended.
+	extended       = regexp.MustCompile(`^` + extendedPattern + `$`)
+	extendedPrefix = regexp.MustCompile(`^` + extendedPattern)
+
+	// lenient and lenientPrefix are the counterparts for ModeLenient.
+	lenient       = regexp.MustCompile(`^` + lenientPattern + `$`)
+	lenientPrefix = regexp.MustCompile(`^` + lenientPattern)
+)
+
+const (
+	pattern = `(?i)(?:(?P<repeat>R)(?P<repetitions>\d+)?/)?(?P<sign>-)?P` +
+		`(?P<year>\d+Y)?(?P<month>\d+M)?(?P<week>\d+W)?(?P<day>\d+D)?` +
+		`(?:(?P<time>T)(?P<hour>\d+H)?(?P<minute>\d+M)?(?P<second>\d+(?:[.,]\d+)?S)?)?`
+	extendedPattern = `(?i)(?:(?P<repeat>R)(?P<repetitions>\d+)?/)?(?P<sign>-)?P` +
+		`(?P<year>-?\d+Y)?(?P<month>-?\d+M)?(?P<week>-?\d+W)?(?P<day>-?\d+D)?` +
+		`(?:(?P<time>T)(?P<hour>-?\d+H)?(?P<minute>-?\d+M)?(?P<second>-?\d+(?:[.,]\d+)?S)?)?`
+	lenientPattern = `(?i)(?:(?P<repeat>R)(?P<repetitions>\d+)?/)?(?P<sign>-)?P?` +
+		`(?P<year>\d+Y)?(?P<month>\d+M)?(?P<week>\d+W)?(?P<day>\d+D)?` +
+		`(?:(?P<time>T)(?P<hour>\d+H)?(?P<minute>\d+M)?(?P<second>\d+(?:[.,]\d+)?S)?)?`
 )
 
 // A Period represents an ISO 8601 period.
+//
+// "Repetitions" has three states:
+// - 0: no repetitions (P1D), the period doesn't occur
+// - n > 0: finite repetitions (R5/P1D), the period occurs n times
+// - Endless: endless repetitions (R/P1D), the period occurs forever
+//
+// Other negative values are invalid: New clamps them to 0 and Validate rejects them.
 type Period struct {
-	Repetitions int `json:"repetitions"`
-	Year        int `json:"year"`
-	Month       int `json:"month"`
-	Day         int `json:"day"`
-	Hour        int `json:"hour"`
-	Minute      int `json:"minute"`
-	Second      int `json:"second"`
-	time        time.Duration
+	Repetitions int  `json:"repetitions"`
+	Year        int  `json:"year"`
+	Month       int  `json:"month"`
+	Week        int  `json:"week"`
+	Day         int  `json:"day"`
+	Hour        int  `json:"hour"`
+	Minute      int  `json:"minute"`
+	Second      int  `json:"second"`
+	Nanosecond  int  `json:"nanosecond"`
+	Negative    bool `json:"negative"`
+	mu          sync.Mutex
 	done        chan bool
 	running     bool
 }
 
-// New generates a new ISO Period.
-func New(now time.Time, repitions int, year int, month int, day int, hour int, minute int, second int) *Period {
-	h := time.Duration(hour) * time.Hour
-	m := time.Duration(minute) * time.Minute
-	s := time.Duration(second) * time.Second
-	t := h + m + s
-
-	period := &Period{
-		Repetitions: repitions,
-		Year:        year,
-		Month:       month,
-		Day:         day,
-		Hour:        hour,
-		Minute:      minute,
-		Second:      second,
-		time:        t,
+// New generates a new ISO Period from the given options.
+//
+// Negative repetitions other than Endless are clamped to 0 (no repetitions),
+// so a typo like -2 doesn't accidentally result in an endless period.
+//
+//	p := isoperiod.New(now, isoperiod.WithRepetitions(5), isoperiod.WithMinutes(30))
+func New(now time.Time, opts ...Option) *Period {
+	period := &Period{}
+	for _, opt := range opts {
+		opt(period)
+	}
+
+	if period.Repetitions < Endless {
+		period.Repetitions = 0
 	}
 
 	return period
 }
 
+// NewFromComponents generates a new ISO Period from positional components.
+//
+// Deprecated: Use New with options instead, the positional arguments are easy to mix up.
+func NewFromComponents(now time.Time, repitions int, year int, month int, day int, hour int, minute int, second int) *Period {
+	return New(
+		now,
+		WithRepetitions(repitions),
+		WithYears(year),
+		WithMonths(month),
+		WithDays(day),
+		WithHours(hour),
+		WithMinutes(minute),
+		WithSeconds(second),
+	)
+}
+
+// Clone returns a copy of the period's components.
+//
+// The ticker state is not copied, so the clone can be started independently.
+// Clone a period before using it in a second goroutine instead of copying it by value.
+func (r *Period) Clone() *Period {
+	p := &Period{}
+	p.assign(r)
+
+	return p
+}
+
+// assign copies the components of p into r.
+// The ticker state of r is left untouched.
+func (r *Period) assign(p *Period) {
+	r.Repetitions = p.Repetitions
+	r.Year = p.Year
+	r.Month = p.Month
+	r.Week = p.Week
+	r.Day = p.Day
+	r.Hour = p.Hour
+	r.Minute = p.Minute
+	r.Second = p.Second
+	r.Nanosecond = p.Nanosecond
+	r.Negative = p.Negative
+}
+
+// sign returns -1 for negative periods and 1 otherwise.
+func (r *Period) sign() int {
+	if r.Negative {
+		return -1
+	}
+
+	return 1
+}
+
+// hms calculates the time portion from the hour, minute, second and nanosecond components.
+// The sign of the period is not applied.
+func (r *Period) hms() time.Duration {
+	h := time.Duration(r.Hour) * time.Hour
+	m := time.Duration(r.Minute) * time.Minute
+	s := time.Duration(r.Second) * time.Second
+	ns := time.Duration(r.Nanosecond)
+
+	return h + m + s + ns
+}
+
 // Parse converts an ISO 8601 string to a period.
 //
 // Currently the following formats are supported:
 // - [Rn/]P[nY][nM][nD]T[nH][nM][nS]
 // - P[nY][nM][nD]T[nH][nM][nS]
+// - [Rn/]P[nW]
+//
+// ISO 8601 doesn't allow combining the week designator with years, months or days.
+// Parse accepts such periods anyway, ParseStrict rejects them (see Validate).
+//
+// Seconds can have a fraction, using either a comma or a dot as decimal sign.
+// Other components only accept whole numbers. The designators are case-insensitive
+// (p1y6m), String always returns them in uppercase.
+//
+// Components that don't fit into an int, as well as hours, minutes and seconds
+// exceeding the range of a time.Duration, result in ErrComponentOverflow.
+//
+// Surrounding whitespace (e.g. from config files) is ignored.
+//
+// As an extension to ISO 8601, a leading sign (e.g. -PT2H) negates the whole period.
 //
 // Examples would be:
 // - P1M (1 Month, no repetitions)
 // - PT1M (1 Minute, no repetitions)
 // - R/PT1M (1 Minute, endless repetitions)
 // - R5/PT30S (30 Seconds, 5 Times)
+// - P3W (3 Weeks, no repetitions)
+// - -PT2H (2 Hours backwards, no repetitions)
+// - PT0,5S (half a Second, no repetitions)
 func Parse(s string) (*Period, error) {
+	return parse(s, compiler, prefix)
+}
+
+// parse converts input to a period using re, which needs the named capture groups of pattern.
+// partial matches a prefix of the input, to find the offset of invalid input.
+// Surrounding whitespace is ignored.
+func parse(input string, re, partial *regexp.Regexp) (*Period, error) {
+	s := strings.TrimSpace(input)
+	lead := len(input) - len(strings.TrimLeftFunc(input, unicode.IsSpace))
+
 	var (
 		result = &Period{
 			Repetitions: 0,
-			time:        time.Duration(0),
 		}
 		err error
 	)
 
-	matches := compiler.FindStringSubmatch(s)
-	if len(matches) == 0 {
-		return nil, errors.New("invalid repeat format")
+	loc := re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return nil, &ParseError{Input: input, Offset: lead + len(partial.FindString(s)), Err: ErrInvalidFormat}
 	}
 
-	if matches[1] == "R" {
-		result.Repetitions = -1
+	// group returns the match of the named capture group, or "" if it didn't participate.
+	group := func(name string) string {
+		i := re.SubexpIndex(name)
+		if loc[2*i] < 0 {
+			return ""
+		}
+
+		return s[loc[2*i]:loc[2*i+1]]
+	}
 
-		if matches[2] != "" {
-			result.Repetitions, err = strconv.Atoi(matches[2])
+	// d is the time portion, only calculated to detect overflows.
+	var d time.Duration
+
+	// fail reports err at the beginning of the named group.
+	fail := func(name string, err error) error {
+		return &ParseError{Input: input, Offset: lead + loc[2*re.SubexpIndex(name)], Err: err}
+	}
+
+	if group("year") == "" && group("month") == "" && group("week") == "" && group("day") == "" &&
+		group("hour") == "" && group("minute") == "" && group("second") == "" {
+		return nil, &ParseError{Input: input, Offset: lead + len(s), Err: ErrEmptyPeriod}
+	}
+
+	if group("time") != "" && group("hour") == "" && group("minute") == "" && group("second") == "" {
+		return nil, &ParseError{
+			Input:  input,
+			Offset: lead + len(s),
+			Err:    fmt.Errorf("%w: time designator without time components", ErrInvalidFormat),
+		}
+	}
+
+	if group("repeat") != "" {
+		result.Repetitions = Endless
+
+		if group("repetitions") != "" {
+			result.Repetitions, err = atoi("repetitions", group("repetitions"))
 			if err != nil {
-				return nil, err
+				return nil, fail("repetitions", err)
 			}
 		}
 	}
 
-	if matches[3] != "" {
-		result.Year, err = strconv.Atoi(matches[3][:len(matches[3])-1])
+	result.Negative = group("sign") == "-"
+
+	if group("year") != "" {
+		result.Year, err = atoi("year", digits(group("year")))
 		if err != nil {
-			return nil, err
+			return nil, fail("year", err)
 		}
 	}
 
-	if matches[4] != "" {
-		result.Month, err = strconv.Atoi(matches[4][:len(matches[4])-1])
+	if group("month") != "" {
+		result.Month, err = atoi("month", digits(group("month")))
 		if err != nil {
-			return nil, err
+			return nil, fail("month", err)
 		}
 	}
 
-	if matches[5] != "" {
-		result.Day, err = strconv.Atoi(matches[5][:len(matches[5])-1])
+	if group("week") != "" {
+		result.Week, err = atoi("week", digits(group("week")))
 		if err != nil {
-			return nil, err
+			return nil, fail("week", err)
 		}
 	}
 
-	if matches[7] != "" {
-		result.Hour, err = strconv.Atoi(matches[7][:len(matches[7])-1])
+	if group("day") != "" {
+		result.Day, err = atoi("day", digits(group("day")))
 		if err != nil {
-			return nil, err
+			return nil, fail("day", err)
+		}
+	}
+
+	if group("hour") != "" {
+		result.Hour, err = atoi("hour", digits(group("hour")))
+		if err != nil {
+			return nil, fail("hour", err)
 		}
-		result.time += time.Duration(result.Hour) * time.Hour
+
+		d, err = addComponent(d, "hour", result.Hour, time.Hour)
+		if err != nil {
+			return nil, fail("hour", err)
+		}
+	}
+
+	if group("minute") != "" {
+		result.Minute, err = atoi("minute", digits(group("minute")))
+		if err != nil {
+			return nil, fail("minute", err)
+		}
+
+		d, err = addComponent(d, "minute", result.Minute, time.Minute)
+		if err != nil {
+			return nil, fail("minute", err)
+		}
+	}
+
+	if group("second") != "" {
+		seconds, fraction, _ := strings.Cut(strings.Replace(digits(group("second")), ",", ".", 1), ".")
+
+		result.Second, err = atoi("second", seconds)
+		if err != nil {
+			return nil, fail("second", err)
+		}
+
+		if fraction != "" {
+			result.Nanosecond = parseFraction(fraction)
+			if strings.HasPrefix(seconds, "-") {
+				result.Nanosecond = -result.Nanosecond
+			}
+		}
+
+		d, err = addComponent(d, "second", result.Second, time.Second)
+		if err == nil {
+			d, err = addComponent(d, "second", result.Nanosecond, 1)
+		}
+		if err != nil {
+			return nil, fail("second", err)
+		}
+	}
+
+	return result, nil
+}
+
+// digits strips the designator from a matched component, e.g. 12H results in 12.
+// Empty matches result in an empty string.
+func digits(match string) string {
+	if len(match) < 2 {
+		return ""
 	}
 
-	if matches[8] != "" {
-		result.Minute, err = strconv.Atoi(matches[8][:len(matches[8])-1])
+	return match[:len(match)-1]
+}
+
+// parseFraction converts the digits after a decimal sign to nanoseconds.
+// Digits beyond nanosecond precision are dropped.
+func parseFraction(digits string) int {
+	if len(digits) > 9 {
+		digits = digits[:9]
+	}
+
+	n, _ := strconv.Atoi(digits + strings.Repeat("0", 9-len(digits)))
+
+	return n
+}
+
+// ParseStrict converts an ISO 8601 string to a period and validates it.
+// It is a shorthand for ParseWithMode(s, ModeStrict).
+func ParseStrict(s string) (*Period, error) {
+	return ParseWithMode(s, ModeStrict)
+}
+
+// ParseWithMode converts an ISO 8601 string to a period using the given mode.
+func ParseWithMode(s string, mode ParseMode) (*Period, error) {
+	switch mode {
+	case ModeLax:
+		return Parse(s)
+	case ModeStrict:
+		p, err := Parse(s)
 		if err != nil {
 			return nil, err
 		}
-		result.time += time.Duration(result.Minute) * time.Minute
+
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+
+		return p, nil
+	case ModeExtended:
+		return parse(s, extended, extendedPrefix)
+	case ModeLenient:
+		return parse(s, lenient, lenientPrefix)
+	default:
+		return nil, fmt.Errorf("unknown parse mode %d", mode)
 	}
+}
 
-	if matches[9] != "" {
-		result.Second, err = strconv.Atoi(matches[9][:len(matches[9])-1])
+// ParseAll converts a list of ISO 8601 strings to periods.
+//
+// The periods are separated by whitespace or commas, e.g. "R5/PT30S, R/P1D".
+// A comma followed by a digit is treated as decimal sign (PT0,5S).
+// If any of the periods is invalid, an error is returned.
+func ParseAll(s string) ([]*Period, error) {
+	var result []*Period
+
+	for _, token := range splitPeriods(s) {
+		p, err := Parse(token)
 		if err != nil {
 			return nil, err
 		}
-		result.time += time.Duration(result.Second) * time.Second
+
+		result = append(result, p)
 	}
 
 	return result, nil
 }
 
+// splitPeriods splits s at whitespace and commas not used as decimal sign.
+func splitPeriods(s string) []string {
+	var (
+		tokens []string
+		start  = -1
+	)
+
+	for i, c := range s {
+		separator := unicode.IsSpace(c) || (c == ',' && (i+1 == len(s) || s[i+1] < '0' || s[i+1] > '9'))
+
+		switch {
+		case separator && start >= 0:
+			tokens = append(tokens, s[start:i])
+			start = -1
+		case !separator && start < 0:
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, s[start:])
+	}
+
+	return tokens
+}
+
 // Next returns the next time the period would be valid.
-// Are there no repetitions left, the result will be an empty time.Time
+// Are there no repetitions left, the result will be an empty time.Time.
+// Finite and endless periods always return the next time.
+//
+// Periods that don't move forward (see Occurrences) have no next time either.
 //
-// Lowering of the "Repetitions" value is up to the user.
+// Lowering of the "Repetitions" value is up to the user, see DecrementRepetitions.
 func (r *Period) Next(now time.Time) time.Time {
 	if r.Repetitions == 0 {
 		return time.Time{}
 	}
 
-	h := time.Hour * time.Duration(r.Hour)
-	m := time.Minute * time.Duration(r.Minute)
-	s := time.Second * time.Duration(r.Second)
+	next, ok := r.step(now, false)
+	if !ok {
+		return time.Time{}
+	}
 
-	return now.AddDate(r.Year, r.Month, r.Day).Add(h + m + s)
+	return next
+}
+
+// NextOnWeekdays is like Next, but skips forward day by day until the result falls on
+// one of the allowed weekdays, keeping the time of day. For a daily period skipping
+// weekends, a Friday results in the following Monday.
+//
+// Are there no repetitions left, or no weekdays allowed, the result will be an empty time.Time.
+func (r *Period) NextOnWeekdays(now time.Time, allowed []time.Weekday) time.Time {
+	next := r.Next(now)
+	if next.IsZero() || len(allowed) == 0 {
+		return time.Time{}
+	}
+
+	for i := 0; i < 7; i++ {
+		for _, day := range allowed {
+			if next.Weekday() == day {
+				return next
+			}
+		}
+
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}
+}
+
+// Until returns the time left from now until the next occurrence.
+// Are there no repetitions left, the result is 0.
+func (r *Period) Until(now time.Time) time.Duration {
+	next := r.Next(now)
+	if next.IsZero() {
+		return 0
+	}
+
+	return next.Sub(now)
+}
+
+// DecrementRepetitions lowers a positive "Repetitions" value by one.
+// Endless periods and periods without repetitions (0) are left untouched.
+//
+// It reports whether any repetitions remain afterwards.
+func (r *Period) DecrementRepetitions() bool {
+	if r.Repetitions > 0 {
+		r.Repetitions--
+	}
+
+	return r.Repetitions != 0
+}
+
+// IsEndless reports whether the period repeats forever.
+func (r *Period) IsEndless() bool {
+	return r.Repetitions == Endless
+}
+
+// IsFinite reports whether the period has a finite amount of repetitions left.
+func (r *Period) IsFinite() bool {
+	return r.Repetitions > 0
+}
+
+// HasNoRepetition reports whether the period has no repetitions (left).
+func (r *Period) HasNoRepetition() bool {
+	return r.Repetitions == 0
 }
 
 // Start returns a read-only channel that triggers whenever the period becomes valid.
+// The first trigger happens one period after calling Start, each following one
+// a period after the previous.
 // After reaching the required amount of repetitions, the channel will be closed.
+// Endless periods trigger until stopped, periods without repetitions close the
+// channel right away.
 //
 // It can also be stopped using the Stop() method.
+// Calling Start on a running period stops the previous ticker.
 func (r *Period) Start() <-chan time.Time {
+	return r.StartContext(context.Background())
+}
+
+// StartContext works like Start, but additionally stops and closes the channel
+// once ctx is done.
+func (r *Period) StartContext(ctx context.Context) <-chan time.Time {
+	return r.StartInLocation(ctx, time.Local)
+}
+
+// StartInLocation works like StartContext, but calculates each trigger with the wall clock
+// of loc: P1D triggers at the same time of day in loc, even across daylight saving transitions.
+// Start and StartContext use time.Local.
+func (r *Period) StartInLocation(ctx context.Context, loc *time.Location) <-chan time.Time {
 	var c <-chan time.Time
 
 	sender := make(chan time.Time)
-	r.done = make(chan bool)
-	ticker := time.NewTicker(1 * time.Second)
-	timeout := time.NewTimer(5 * time.Second)
-	amount := r.Repetitions
-
 	c = sender
 
-	go func() {
-		r.running = true
+	now := time.Now()
+	next := r.AddInLocation(now, loc)
+	if r.Repetitions == 0 || !next.After(now) {
+		// No repetitions, or a period without length that would fire continuously
+		close(sender)
+		return c
+	}
 
-		defer timeout.Stop()
-		defer ticker.Stop()
+	r.mu.Lock()
+	if r.running {
+		close(r.done)
+	}
+	done := make(chan bool)
+	r.done = done
+	r.running = true
+	r.mu.Unlock()
+
+	timer := time.NewTimer(next.Sub(now))
+	amount := r.Repetitions
+
+	go func() {
+		defer timer.Stop()
 		defer close(sender)
-		defer close(r.done)
 		defer func() {
-			r.running = false
+			r.mu.Lock()
+			if r.done == done {
+				r.running = false
+			}
+			r.mu.Unlock()
 		}()
 
 		for {
 			select {
-			case t := <-ticker.C:
+			case t := <-timer.C:
 				select {
 				case sender <- t:
 				default:
@@ -184,16 +596,16 @@ func (r *Period) Start() <-chan time.Time {
 					amount--
 				}
 				if amount == 0 {
-					select {
-					case r.done <- true:
-					default:
-					}
-
 					return
 				}
-			case <-r.done:
+
+				next = r.AddInLocation(next, loc)
+				timer.Reset(time.Until(next))
+			case <-done:
 				// End it all
 				return
+			case <-ctx.Done():
+				return
 			}
 		}
 	}()
@@ -202,66 +614,22 @@ func (r *Period) Start() <-chan time.Time {
 }
 
 // Stop stops the running ticker started with the Start() method.
-// If no ticker is active, it won't do anything.
+// If no ticker is active, it won't do anything. It is safe to call Stop
+// multiple times, before Start or after the repetitions are exhausted.
 func (r *Period) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if !r.running {
 		return
 	}
 
-	r.done <- true
+	close(r.done)
+	r.running = false
 }
 
+// String returns the ISO 8601 representation of the period.
+// A period without any components results in PT0S, which parses back to a zero period.
 func (r *Period) String() string {
-	result := ""
-	timeAdded := false
-	tAdded := false
-
-	if r.Repetitions == 0 {
-		result += "R/"
-	} else if r.Repetitions > 0 {
-		result += "R" + strconv.Itoa(r.Repetitions) + "/"
-	}
-
-	result += "P"
-
-	if r.Year > 0 {
-		result += strconv.Itoa(r.Year) + "Y"
-		timeAdded = true
-	}
-	if r.Month > 0 {
-		result += strconv.Itoa(r.Month) + "M"
-		timeAdded = true
-	}
-	if r.Day > 0 {
-		result += strconv.Itoa(r.Day) + "D"
-		timeAdded = true
-	}
-
-	if r.Hour > 0 {
-		result += "T" + strconv.Itoa(r.Hour) + "H"
-		timeAdded = true
-		tAdded = true
-	}
-	if r.Minute > 0 {
-		if !tAdded {
-			result += "T"
-			tAdded = true
-		}
-		result += strconv.Itoa(r.Minute) + "M"
-		timeAdded = true
-	}
-	if r.Second > 0 {
-		if !tAdded {
-			result += "T"
-			tAdded = true
-		}
-		result += strconv.Itoa(r.Second) + "S"
-		timeAdded = true
-	}
-
-	if !timeAdded {
-		result += "T0S"
-	}
-
-	return result
+	return r.Format(FormatOptions{})
 }
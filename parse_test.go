@@ -1,7 +1,11 @@
 package isoperiod_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -45,6 +49,7 @@ func TestParse(t *testing.T) {
 		Repetitions int
 		Year        int
 		Month       int
+		Week        int
 		Day         int
 		Hour        int
 		Minute      int
@@ -85,6 +90,91 @@ func TestParse(t *testing.T) {
 			S:           "R20/P1Y6M2DT2H",
 			Err:         nil,
 		},
+		{
+			Repetitions: 0,
+			Week:        3,
+			S:           "P3W",
+			Err:         nil,
+		},
+		{
+			Repetitions: 5,
+			Week:        2,
+			Hour:        12,
+			S:           "R5/P2WT12H",
+			Err:         nil,
+		},
+		{
+			Week: 1,
+			Day:  2,
+			S:    "P1W2D",
+			Err:  nil,
+		},
+		{
+			Year: 1,
+			Week: 1,
+			S:    "P1Y1W",
+			Err:  nil,
+		},
+		{
+			S:   "xxP1M",
+			Err: errors.New("parsing \"xxP1M\" at offset 0: invalid period format"),
+		},
+		{
+			Month: 1,
+			S:     "P1M ",
+			Err:   nil,
+		},
+		{
+			S:   "P1Mfoo",
+			Err: errors.New("parsing \"P1Mfoo\" at offset 3: invalid period format"),
+		},
+		{
+			S:   "total nonsense",
+			Err: errors.New("parsing \"total nonsense\" at offset 0: invalid period format"),
+		},
+		{
+			S:   "5/P1M",
+			Err: errors.New("parsing \"5/P1M\" at offset 0: invalid period format"),
+		},
+		{
+			S:   "P",
+			Err: errors.New("parsing \"P\" at offset 1: period has no components"),
+		},
+		{
+			S:   "PT",
+			Err: errors.New("parsing \"PT\" at offset 2: period has no components"),
+		},
+		{
+			S:   "R5/P",
+			Err: errors.New("parsing \"R5/P\" at offset 4: period has no components"),
+		},
+		{
+			Repetitions: 0,
+			S:           "PT0S",
+			Err:         nil,
+		},
+		{
+			S:   "P1H",
+			Err: errors.New("parsing \"P1H\" at offset 1: invalid period format"),
+		},
+		{
+			S:   "P1D2H",
+			Err: errors.New("parsing \"P1D2H\" at offset 3: invalid period format"),
+		},
+		{
+			S:   "P1DT",
+			Err: errors.New("parsing \"P1DT\" at offset 4: invalid period format: time designator without time components"),
+		},
+		{
+			Month: 1,
+			S:     "P1M",
+			Err:   nil,
+		},
+		{
+			Minute: 1,
+			S:      "PT1M",
+			Err:    nil,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -95,6 +185,10 @@ func TestParse(t *testing.T) {
 			continue
 		}
 
+		if testCase.Err != nil {
+			continue
+		}
+
 		if p.Repetitions != testCase.Repetitions {
 			t.Errorf("Repetitions %d != %d", p.Repetitions, testCase.Repetitions)
 		}
@@ -107,6 +201,10 @@ func TestParse(t *testing.T) {
 			t.Errorf("Month %d != %d", p.Month, testCase.Month)
 		}
 
+		if p.Week != testCase.Week {
+			t.Errorf("Week %d != %d", p.Week, testCase.Week)
+		}
+
 		if p.Day != testCase.Day {
 			t.Errorf("Day %d != %d", p.Day, testCase.Day)
 		}
@@ -131,15 +229,15 @@ func TestNext(t *testing.T) {
 		Next   time.Time
 	}{
 		{
-			Period: isoperiod.New(now, 0, 3, 9, 7, 12, 30, 50),
+			Period: isoperiod.New(now, isoperiod.WithYears(3), isoperiod.WithMonths(9), isoperiod.WithDays(7), isoperiod.WithHours(12), isoperiod.WithMinutes(30), isoperiod.WithSeconds(50)),
 			Next:   time.Time{},
 		},
 		{
-			Period: isoperiod.New(now, 0, 0, 1, 12, 2, 3, 5),
+			Period: isoperiod.New(now, isoperiod.WithMonths(1), isoperiod.WithDays(12), isoperiod.WithHours(2), isoperiod.WithMinutes(3), isoperiod.WithSeconds(5)),
 			Next:   time.Time{},
 		},
 		{
-			Period: isoperiod.New(now, 20, 1, 6, 2, 2, 0, 0),
+			Period: isoperiod.New(now, isoperiod.WithRepetitions(20), isoperiod.WithYears(1), isoperiod.WithMonths(6), isoperiod.WithDays(2), isoperiod.WithHours(2)),
 			Next:   calcTime(1, 6, 2, 2, 0, 0),
 		},
 	}
@@ -151,3 +249,980 @@ func TestNext(t *testing.T) {
 		}
 	}
 }
+
+func TestNextRepetitions(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Next time.Time
+	}{
+		{
+			S:    "R/PT1M",
+			Next: now.Add(time.Minute),
+		},
+		{
+			S:    "R3/PT1M",
+			Next: now.Add(time.Minute),
+		},
+		{
+			S:    "P1D",
+			Next: time.Time{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		// Next never changes the repetitions, so repeated calls return the same time.
+		for i := 0; i < 5; i++ {
+			if next := p.Next(now); !testCase.Next.Equal(next) {
+				t.Errorf("%s: next is %s but should be %s", testCase.S, next, testCase.Next)
+			}
+		}
+	}
+
+	p, err := isoperiod.Parse("R3/PT1M")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for p.DecrementRepetitions() {
+		if next := p.Next(now); next.IsZero() {
+			t.Errorf("next is zero but should be set with %d repetitions left", p.Repetitions)
+		}
+	}
+
+	if next := p.Next(now); !next.IsZero() {
+		t.Errorf("next is %s but should be zero once exhausted", next)
+	}
+}
+
+func TestNextOnWeekdays(t *testing.T) {
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	testTable := []struct {
+		S       string
+		Now     string
+		Allowed []time.Weekday
+		Next    string
+	}{
+		{
+			S:       "R/P1D",
+			Now:     "2023-01-02T09:00:00Z", // Monday
+			Allowed: weekdays,
+			Next:    "2023-01-03T09:00:00Z",
+		},
+		{
+			S:       "R/P1D",
+			Now:     "2023-01-06T09:00:00Z", // Friday
+			Allowed: weekdays,
+			Next:    "2023-01-09T09:00:00Z",
+		},
+		{
+			S:       "R/P1D",
+			Now:     "2023-01-07T09:00:00Z", // Saturday
+			Allowed: weekdays,
+			Next:    "2023-01-09T09:00:00Z",
+		},
+		{
+			S:       "R/PT12H",
+			Now:     "2023-01-06T20:00:00Z", // Friday
+			Allowed: []time.Weekday{time.Wednesday},
+			Next:    "2023-01-11T08:00:00Z",
+		},
+		{
+			S:       "P1D",
+			Now:     "2023-01-02T09:00:00Z",
+			Allowed: weekdays,
+			Next:    "",
+		},
+		{
+			S:       "R/P1D",
+			Now:     "2023-01-02T09:00:00Z",
+			Allowed: nil,
+			Next:    "",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		start, _ := time.Parse(time.RFC3339, testCase.Now)
+		var want time.Time
+		if testCase.Next != "" {
+			want, _ = time.Parse(time.RFC3339, testCase.Next)
+		}
+
+		if next := p.NextOnWeekdays(start, testCase.Allowed); !next.Equal(want) {
+			t.Errorf("%s from %s: next is %s but should be %s", testCase.S, testCase.Now, next, want)
+		}
+	}
+}
+
+func TestUntil(t *testing.T) {
+	testTable := []struct {
+		S     string
+		Until time.Duration
+	}{
+		{
+			S:     "R/PT1M30S",
+			Until: 90 * time.Second,
+		},
+		{
+			S:     "R3/P1D",
+			Until: 24 * time.Hour,
+		},
+		{
+			S:     "PT1M",
+			Until: 0,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if d := p.Until(now); d != testCase.Until {
+			t.Errorf("%s: until is %s but should be %s", testCase.S, d, testCase.Until)
+		}
+	}
+
+	p, err := isoperiod.Parse("R1/PT1M")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.DecrementRepetitions()
+	if d := p.Until(now); d != 0 {
+		t.Errorf("until is %s but should be 0s once exhausted", d)
+	}
+}
+
+func TestStart(t *testing.T) {
+	p, err := isoperiod.Parse("R2/PT2S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := time.Now()
+	fired := 0
+	for range p.Start() {
+		if elapsed := time.Since(last); elapsed < 1500*time.Millisecond || elapsed > 3*time.Second {
+			t.Errorf("fired after %s but should be after 2s", elapsed)
+		}
+
+		last = time.Now()
+		fired++
+	}
+
+	if fired != 2 {
+		t.Errorf("fired %d times but should be 2", fired)
+	}
+}
+
+func TestStartContext(t *testing.T) {
+	p, err := isoperiod.Parse("R/PT1S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	begin := time.Now()
+	for range p.StartContext(ctx) {
+		t.Error("fired but should have been cancelled")
+	}
+
+	if elapsed := time.Since(begin); elapsed > 500*time.Millisecond {
+		t.Errorf("closed after %s but should be closed after 100ms", elapsed)
+	}
+}
+
+func TestStartStopConcurrent(t *testing.T) {
+	p, err := isoperiod.Parse("R/PT1H")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		channels []<-chan time.Time
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			c := p.Start()
+			mu.Lock()
+			channels = append(channels, c)
+			mu.Unlock()
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			p.Stop()
+		}()
+	}
+
+	wg.Wait()
+	p.Stop()
+
+	for _, c := range channels {
+		select {
+		case _, ok := <-c:
+			if ok {
+				t.Error("fired but should have been stopped")
+			}
+		case <-time.After(time.Second):
+			t.Error("channel wasn't closed")
+		}
+	}
+}
+
+func TestStop(t *testing.T) {
+	p, err := isoperiod.Parse("R1/PT1S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Before Start
+	p.Stop()
+
+	// Twice in a row
+	c := p.Start()
+	p.Stop()
+	p.Stop()
+	if _, ok := <-c; ok {
+		t.Error("fired but should have been stopped")
+	}
+
+	// After natural completion
+	fired := 0
+	for range p.Start() {
+		fired++
+	}
+	p.Stop()
+	p.Stop()
+
+	if fired != 1 {
+		t.Errorf("fired %d times but should be 1", fired)
+	}
+}
+
+func TestParseMonthMinute(t *testing.T) {
+	testTable := []struct {
+		S      string
+		Month  int
+		Day    int
+		Minute int
+		Err    error
+	}{
+		{
+			S:      "P1M1DT1M",
+			Month:  1,
+			Day:    1,
+			Minute: 1,
+			Err:    nil,
+		},
+		{
+			S:      "P2MT3M",
+			Month:  2,
+			Minute: 3,
+			Err:    nil,
+		},
+		{
+			S:      "PT5M",
+			Minute: 5,
+			Err:    nil,
+		},
+		{
+			S:     "P5M",
+			Month: 5,
+			Err:   nil,
+		},
+		{
+			S:   "P1M1M",
+			Err: errors.New("parsing \"P1M1M\" at offset 3: invalid period format"),
+		},
+		{
+			S:   "PT1M1M",
+			Err: errors.New("parsing \"PT1M1M\" at offset 4: invalid period format"),
+		},
+		{
+			S:   "P1DT1M1H",
+			Err: errors.New("parsing \"P1DT1M1H\" at offset 6: invalid period format"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		if p.Month != testCase.Month {
+			t.Errorf("%s: Month %d != %d", testCase.S, p.Month, testCase.Month)
+		}
+
+		if p.Day != testCase.Day {
+			t.Errorf("%s: Day %d != %d", testCase.S, p.Day, testCase.Day)
+		}
+
+		if p.Minute != testCase.Minute {
+			t.Errorf("%s: Minute %d != %d", testCase.S, p.Minute, testCase.Minute)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	p, err := isoperiod.Parse("R1/PT1S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := p.Start()
+	clone := p.Clone()
+	if !clone.Equal(p) {
+		t.Errorf("clone is %s but should be %s", clone, p)
+	}
+
+	cloned := clone.Start()
+	p.Stop()
+
+	if _, ok := <-original; ok {
+		t.Error("original fired but should have been stopped")
+	}
+
+	fired := 0
+	for range cloned {
+		fired++
+	}
+
+	if fired != 1 {
+		t.Errorf("clone fired %d times but should be 1", fired)
+	}
+}
+
+func TestDecrementRepetitions(t *testing.T) {
+	testTable := []struct {
+		S           string
+		Repetitions int
+		Remaining   bool
+	}{
+		{
+			S:           "R2/PT1M",
+			Repetitions: 1,
+			Remaining:   true,
+		},
+		{
+			S:           "R1/PT1M",
+			Repetitions: 0,
+			Remaining:   false,
+		},
+		{
+			S:           "R/PT1M",
+			Repetitions: -1,
+			Remaining:   true,
+		},
+		{
+			S:           "PT1M",
+			Repetitions: 0,
+			Remaining:   false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if remaining := p.DecrementRepetitions(); remaining != testCase.Remaining {
+			t.Errorf("%s: remaining is %t but should be %t", testCase.S, remaining, testCase.Remaining)
+		}
+
+		if p.Repetitions != testCase.Repetitions {
+			t.Errorf("%s: Repetitions %d != %d", testCase.S, p.Repetitions, testCase.Repetitions)
+		}
+	}
+}
+
+func TestParseFraction(t *testing.T) {
+	testTable := []struct {
+		S        string
+		Duration time.Duration
+		String   string
+	}{
+		{
+			S:        "R1/PT0.5S",
+			Duration: 500 * time.Millisecond,
+			String:   "R1/PT0.5S",
+		},
+		{
+			S:        "R1/PT0,5S",
+			Duration: 500 * time.Millisecond,
+			String:   "R1/PT0.5S",
+		},
+		{
+			S:        "R1/PT1M1,25S",
+			Duration: time.Minute + 1250*time.Millisecond,
+			String:   "R1/PT1M1.25S",
+		},
+		{
+			S:        "R1/PT0.0000000019S",
+			Duration: time.Nanosecond,
+			String:   "R1/PT0.000000001S",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if d := p.Duration(); d != testCase.Duration {
+			t.Errorf("%s: duration is %s but should be %s", testCase.S, d, testCase.Duration)
+		}
+
+		if s := p.String(); s != testCase.String {
+			t.Errorf("%s: period is %s but should be %s", testCase.S, s, testCase.String)
+		}
+	}
+
+	for _, s := range []string{"PT0.S", "PT,5S", "P0.5D", "PT0.5M"} {
+		if _, err := isoperiod.Parse(s); err == nil {
+			t.Errorf("%s: error is nil but should be set", s)
+		}
+	}
+}
+
+func TestRepetitionStates(t *testing.T) {
+	testTable := []struct {
+		S           string
+		Repetitions int
+		Next        bool
+		Occurrences int
+	}{
+		{
+			S:           "P1D",
+			Repetitions: 0,
+			Next:        false,
+			Occurrences: 0,
+		},
+		{
+			S:           "R3/P1D",
+			Repetitions: 3,
+			Next:        true,
+			Occurrences: 3,
+		},
+		{
+			S:           "R/P1D",
+			Repetitions: isoperiod.Endless,
+			Next:        true,
+			Occurrences: 10,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if p.Repetitions != testCase.Repetitions {
+			t.Errorf("%s: Repetitions %d != %d", testCase.S, p.Repetitions, testCase.Repetitions)
+		}
+
+		if next := p.Next(now); next.IsZero() == testCase.Next {
+			t.Errorf("%s: next is %s", testCase.S, next)
+		}
+
+		if n := len(collect(p.Occurrences(now), 10)); n != testCase.Occurrences {
+			t.Errorf("%s: %d occurrences but should be %d", testCase.S, n, testCase.Occurrences)
+		}
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("period is %s but should be %s", s, testCase.S)
+		}
+	}
+
+	p, err := isoperiod.Parse("PT1S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case _, ok := <-p.Start():
+		if ok {
+			t.Error("fired but should be closed")
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("channel wasn't closed")
+	}
+}
+
+func TestRepeats(t *testing.T) {
+	testTable := []struct {
+		S               string
+		Endless         bool
+		Finite          bool
+		HasNoRepetition bool
+	}{
+		{
+			S:               "PT1M",
+			HasNoRepetition: true,
+		},
+		{
+			S:      "R5/PT1M",
+			Finite: true,
+		},
+		{
+			S:       "R/PT1M",
+			Endless: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if p.IsEndless() != testCase.Endless {
+			t.Errorf("%s: IsEndless should be %t", testCase.S, testCase.Endless)
+		}
+
+		if p.IsFinite() != testCase.Finite {
+			t.Errorf("%s: IsFinite should be %t", testCase.S, testCase.Finite)
+		}
+
+		if p.HasNoRepetition() != testCase.HasNoRepetition {
+			t.Errorf("%s: HasNoRepetition should be %t", testCase.S, testCase.HasNoRepetition)
+		}
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	testTable := []struct {
+		S       string
+		Periods []string
+		Err     error
+	}{
+		{
+			S:       "R5/PT30S R/P1D",
+			Periods: []string{"R5/PT30S", "R/P1D"},
+			Err:     nil,
+		},
+		{
+			S:       " P1Y,PT0,5S,\tR2/P3W ",
+			Periods: []string{"P1Y", "PT0.5S", "R2/P3W"},
+			Err:     nil,
+		},
+		{
+			S:       "",
+			Periods: nil,
+			Err:     nil,
+		},
+		{
+			S:   "P1Y, Pfoo, P1D",
+			Err: errors.New(`parsing "Pfoo" at offset 1: invalid period format`),
+		},
+	}
+
+	for _, testCase := range testTable {
+		periods, err := isoperiod.ParseAll(testCase.S)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if len(periods) != len(testCase.Periods) {
+			t.Errorf("%q: got %d periods but should be %d", testCase.S, len(periods), len(testCase.Periods))
+			continue
+		}
+
+		for i, p := range periods {
+			if p.String() != testCase.Periods[i] {
+				t.Errorf("%q: period %d is %s but should be %s", testCase.S, i, p, testCase.Periods[i])
+			}
+		}
+	}
+}
+
+func TestParseGroups(t *testing.T) {
+	testTable := []struct {
+		S     string
+		Units map[string]int
+	}{
+		{
+			S:     "P7Y",
+			Units: map[string]int{"year": 7},
+		},
+		{
+			S:     "P7M",
+			Units: map[string]int{"month": 7},
+		},
+		{
+			S:     "P7W",
+			Units: map[string]int{"week": 7},
+		},
+		{
+			S:     "P7D",
+			Units: map[string]int{"day": 7},
+		},
+		{
+			S:     "PT7H",
+			Units: map[string]int{"hour": 7},
+		},
+		{
+			S:     "PT7M",
+			Units: map[string]int{"minute": 7},
+		},
+		{
+			S:     "PT7.25S",
+			Units: map[string]int{"second": 7, "nanosecond": 250000000},
+		},
+		{
+			S:     "P1Y2M3W4DT5H6M7S",
+			Units: map[string]int{"year": 1, "month": 2, "week": 3, "day": 4, "hour": 5, "minute": 6, "second": 7},
+		},
+	}
+
+	for _, testCase := range testTable {
+		for _, mode := range []isoperiod.ParseMode{isoperiod.ModeLax, isoperiod.ModeExtended} {
+			p, err := isoperiod.ParseWithMode("R9/-"+testCase.S, mode)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+
+			if p.Repetitions != 9 {
+				t.Errorf("%s: repetitions are %d but should be 9", testCase.S, p.Repetitions)
+			}
+
+			if !p.Negative {
+				t.Errorf("%s: period should be negative", testCase.S)
+			}
+
+			p.Negative = false
+			if units := p.InUnits(); !reflect.DeepEqual(units, testCase.Units) {
+				t.Errorf("%s: units are %v but should be %v", testCase.S, units, testCase.Units)
+			}
+		}
+	}
+}
+
+func TestParseWhitespace(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Want string
+	}{
+		{
+			S:    "\tP1M",
+			Want: "P1M",
+		},
+		{
+			S:    "R5/PT30S\n",
+			Want: "R5/PT30S",
+		},
+		{
+			S:    "  -P1DT2H  ",
+			Want: "-P1DT2H",
+		},
+		{
+			S:    "\r\n PT0,5S \r\n",
+			Want: "PT0.5S",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := p.String(); s != testCase.Want {
+			t.Errorf("%q: period is %s but should be %s", testCase.S, s, testCase.Want)
+		}
+	}
+
+	// Offsets still refer to the original input.
+	_, err := isoperiod.Parse("  P1Mfoo ")
+	if err := checkError(errors.New(`parsing "  P1Mfoo " at offset 5: invalid period format`), err); err != nil {
+		t.Error(err)
+	}
+
+	_, err = isoperiod.Parse(" \t ")
+	if err := checkError(errors.New(`parsing " \t " at offset 3: invalid period format`), err); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseCase(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Want string
+	}{
+		{
+			S:    "p1y6m",
+			Want: "P1Y6M",
+		},
+		{
+			S:    "P1YT1h",
+			Want: "P1YT1H",
+		},
+		{
+			S:    "r5/pt30m",
+			Want: "R5/PT30M",
+		},
+		{
+			S:    "p1mt1m",
+			Want: "P1MT1M",
+		},
+		{
+			S:    "-p1dT0,5s",
+			Want: "-P1DT0.5S",
+		},
+		{
+			S:    "r/P2w",
+			Want: "R/P2W",
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if s := p.String(); s != testCase.Want {
+			t.Errorf("%q: period is %s but should be %s", testCase.S, s, testCase.Want)
+		}
+	}
+
+	// The T separator still tells months and minutes apart.
+	p, err := isoperiod.Parse("p1m")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Month != 1 || p.Minute != 0 {
+		t.Errorf("p1m has %d months and %d minutes but should be 1 and 0", p.Month, p.Minute)
+	}
+
+	_, err = isoperiod.Parse("p1h")
+	if err := checkError(errors.New(`parsing "p1h" at offset 1: invalid period format`), err); err != nil {
+		t.Error(err)
+	}
+
+	if isoperiod.IsCanonical("p1m") {
+		t.Error("p1m shouldn't be canonical")
+	}
+}
+
+func TestParseEmptyGroups(t *testing.T) {
+	testTable := []struct {
+		S  string
+		OK bool
+	}{
+		{
+			S:  "R/P1D",
+			OK: true,
+		},
+		{
+			S:  "R/PT1S",
+			OK: true,
+		},
+		{
+			S:  "P1Y",
+			OK: true,
+		},
+		{
+			S:  "P1M",
+			OK: true,
+		},
+		{
+			S:  "P1W",
+			OK: true,
+		},
+		{
+			S:  "P1D",
+			OK: true,
+		},
+		{
+			S:  "PT1H",
+			OK: true,
+		},
+		{
+			S:  "PT1M",
+			OK: true,
+		},
+		{
+			S:  "PT1S",
+			OK: true,
+		},
+		{
+			S:  "R/",
+			OK: false,
+		},
+		{
+			S:  "R/P",
+			OK: false,
+		},
+		{
+			S:  "R/PT",
+			OK: false,
+		},
+		{
+			S:  "RP1D",
+			OK: false,
+		},
+		{
+			S:  "PY",
+			OK: false,
+		},
+		{
+			S:  "PM",
+			OK: false,
+		},
+		{
+			S:  "PW",
+			OK: false,
+		},
+		{
+			S:  "PD",
+			OK: false,
+		},
+		{
+			S:  "PTH",
+			OK: false,
+		},
+		{
+			S:  "PTM",
+			OK: false,
+		},
+		{
+			S:  "PTS",
+			OK: false,
+		},
+		{
+			S:  "PT.5S",
+			OK: false,
+		},
+		{
+			S:  "PT1.S",
+			OK: false,
+		},
+		{
+			S:  "P1YT",
+			OK: false,
+		},
+		{
+			S:  "-",
+			OK: false,
+		},
+		{
+			S:  "",
+			OK: false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		_, err := isoperiod.Parse(testCase.S)
+		if ok := err == nil; ok != testCase.OK {
+			t.Errorf("%q: ok is %t but should be %t (%v)", testCase.S, ok, testCase.OK, err)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"R5/PT30S", "R/P1Y2M3DT4H5M6.5S", "-P3W", "PT0,5S", "P1M1M", "R/", "PT"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		p, err := isoperiod.Parse(s)
+		if err != nil {
+			return
+		}
+
+		q, err := isoperiod.Parse(p.String())
+		if err != nil {
+			t.Fatalf("%q: can't parse %s: %s", s, p, err)
+		}
+
+		if !q.Equal(p) {
+			t.Errorf("%q: round-trip is %s but should be %s", s, q, p)
+		}
+	})
+}
+
+func TestStartInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	p, err := isoperiod.Parse("R3/PT0.1S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	begin := time.Now()
+	var fired []time.Time
+	for tick := range p.StartInLocation(context.Background(), loc) {
+		fired = append(fired, tick)
+	}
+
+	if len(fired) != 3 {
+		t.Fatalf("fired %d times but should be 3", len(fired))
+	}
+
+	// Each trigger is rescheduled a period after the previous one.
+	prev := begin
+	for i, tick := range fired {
+		if d := tick.Sub(prev); d < 90*time.Millisecond || d > 300*time.Millisecond {
+			t.Errorf("trigger %d is %s after the previous but should be 100ms", i, d)
+		}
+
+		prev = tick
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := p.StartInLocation(ctx, loc)
+	cancel()
+
+	for range c {
+		t.Error("fired but should have been cancelled")
+	}
+}
@@ -0,0 +1,160 @@
+package isoperiod
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// A ScheduleOption configures Schedule.
+type ScheduleOption func(*schedule)
+
+// schedule holds the configuration of Schedule.
+type schedule struct {
+	jitter float64
+	rng    *rand.Rand
+	loc    *time.Location
+}
+
+// WithJitter delays each call by a random amount of up to fraction times the length
+// of the period, so many instances sharing a schedule don't fire at the same instant.
+// The fraction is clamped to [0, 1].
+func WithJitter(fraction float64) ScheduleOption {
+	return func(s *schedule) {
+		switch {
+		case fraction < 0:
+			s.jitter = 0
+		case fraction > 1:
+			s.jitter = 1
+		default:
+			s.jitter = fraction
+		}
+	}
+}
+
+// WithRand sets the random number generator used for the jitter, e.g. a seeded one in tests.
+// By default the global generator of math/rand is used.
+func WithRand(rng *rand.Rand) ScheduleOption {
+	return func(s *schedule) {
+		s.rng = rng
+	}
+}
+
+// WithLocation calculates the occurrences with the wall clock of loc, so P1D fires
+// at the same time of day in loc, even across daylight saving transitions.
+// By default the location of start is used.
+func WithLocation(loc *time.Location) ScheduleOption {
+	return func(s *schedule) {
+		s.loc = loc
+	}
+}
+
+// delay returns the jitter for the occurrence at t of the period r.
+func (s *schedule) delay(r *Period, t time.Time) time.Duration {
+	if s.jitter == 0 {
+		return 0
+	}
+
+	f := rand.Float64
+	if s.rng != nil {
+		f = s.rng.Float64
+	}
+
+	return time.Duration(f() * s.jitter * float64(r.Add(t).Sub(t)))
+}
+
+// Schedule calls fn at each occurrence of the period, beginning with start (see Occurrences).
+// fn is called from a separate goroutine, one call at a time, and receives the scheduled time,
+// even if the call was delayed with WithJitter.
+//
+// start is always called, right away if it has already passed, so Schedule(time.Now(), fn)
+// doesn't lose an occurrence. The following occurrences before the call to Schedule are
+// skipped, but still count towards the repetitions.
+//
+// The returned stop function cancels the schedule. fn isn't called anymore once stop
+// returns, except for a call already in progress. Periods without repetitions, as well
+// as periods without length, never call fn. Like Occurrences, the schedule stops at the first
+// occurrence that isn't after the previous one.
+func (r *Period) Schedule(start time.Time, fn func(time.Time), opts ...ScheduleOption) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cfg := &schedule{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.loc != nil {
+		start = start.In(cfg.loc)
+	}
+
+	if _, ok := r.step(start, false); r.Repetitions == 0 || !ok {
+		return cancel
+	}
+
+	now := time.Now()
+
+	go func() {
+		t := start
+		for i := 0; r.Repetitions == Endless || i < r.Repetitions; i++ {
+			timer := time.NewTimer(time.Until(t) + cfg.delay(r, t))
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			fn(t)
+
+			next, skipped, ok := r.catchUp(t, now)
+			if !ok {
+				return
+			}
+
+			t = next
+			i += skipped
+		}
+	}()
+
+	return cancel
+}
+
+// catchUp returns the first occurrence after t that isn't before now, and how many
+// occurrences before now were skipped on the way.
+//
+// Periods without calendar components have a fixed length, so the occurrence is calculated
+// directly instead of stepping through a long backlog one by one. ok is false if a step doesn't
+// move forward (see Occurrences).
+func (r *Period) catchUp(t, now time.Time) (next time.Time, skipped int, ok bool) {
+	next, ok = r.step(t, false)
+	if !ok {
+		return next, 0, false
+	}
+
+	if next.Before(now) && r.Year == 0 && r.Month == 0 && r.Week == 0 && r.Day == 0 {
+		d := next.Sub(t)
+		n := (now.Sub(next) + d - 1) / d
+		if n > 0 && n <= math.MaxInt {
+			next = next.Add(n * d)
+			skipped = int(n)
+		}
+	}
+
+	for next.Before(now) {
+		following, moved := r.step(next, false)
+		if !moved {
+			return following, skipped, false
+		}
+
+		next = following
+		skipped++
+	}
+
+	return next, skipped, true
+}
@@ -0,0 +1,316 @@
+package isoperiod_test
+
+import (
+	"math/rand"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestSchedule(t *testing.T) {
+	p, err := isoperiod.Parse("R3/PT0.05S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now().Add(50 * time.Millisecond)
+	fired := make(chan time.Time, 10)
+	stop := p.Schedule(start, func(t time.Time) {
+		fired <- t
+	})
+	defer stop()
+
+	want := p.Times(start)
+	for i, w := range want {
+		select {
+		case got := <-fired:
+			if !got.Equal(w) {
+				t.Errorf("occurrence %d is %s but should be %s", i, got, w)
+			}
+
+			if now := time.Now(); now.Before(w) {
+				t.Errorf("occurrence %d fired at %s, before %s", i, now, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("fired %d times but should be %d", i, len(want))
+		}
+	}
+
+	select {
+	case got := <-fired:
+		t.Errorf("fired at %s but should have stopped after %d times", got, len(want))
+	case <-time.After(150 * time.Millisecond):
+	}
+}
+
+func TestScheduleNow(t *testing.T) {
+	p, err := isoperiod.Parse("R3/PT0.2S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	fired := make(chan time.Time, 10)
+	stop := p.Schedule(start, func(t time.Time) {
+		fired <- t
+	})
+	defer stop()
+
+	want := p.Times(start)
+	for i, w := range want {
+		select {
+		case got := <-fired:
+			if !got.Equal(w) {
+				t.Errorf("occurrence %d is %s but should be %s", i, got, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("fired %d times but should be %d", i, len(want))
+		}
+	}
+
+	select {
+	case got := <-fired:
+		t.Errorf("fired at %s but should have stopped after %d times", got, len(want))
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestSchedulePast(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Ago  time.Duration
+		Want int
+	}{
+		{
+			S:    "R/PT0.5S",
+			Ago:  365 * 24 * time.Hour,
+			Want: 2,
+		},
+		{
+			S:    "R/P1D",
+			Ago:  3 * 365 * 24 * time.Hour,
+			Want: 1,
+		},
+		{
+			S:    "R5/PT1S",
+			Ago:  time.Hour,
+			Want: 1,
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		start := time.Now().Add(-testCase.Ago)
+		fired := make(chan time.Time, 10)
+		stop := p.Schedule(start, func(t time.Time) {
+			fired <- t
+		})
+
+		// start is called right away, the skipped backlog doesn't delay the next occurrence.
+		time.Sleep(750 * time.Millisecond)
+		stop()
+
+		if n := len(fired); n != testCase.Want {
+			t.Errorf("%s: fired %d times but should be %d", testCase.S, n, testCase.Want)
+			continue
+		}
+
+		if got := <-fired; !got.Equal(start) {
+			t.Errorf("%s: first occurrence is %s but should be %s", testCase.S, got, start)
+		}
+	}
+}
+
+func TestScheduleStop(t *testing.T) {
+	p, err := isoperiod.Parse("R/PT0.02S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fired int32
+	stop := p.Schedule(time.Now(), func(time.Time) {
+		atomic.AddInt32(&fired, 1)
+	})
+
+	time.Sleep(100 * time.Millisecond)
+	stop()
+	stopped := atomic.LoadInt32(&fired)
+
+	if stopped == 0 {
+		t.Error("fired 0 times but should have fired before stop")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if n := atomic.LoadInt32(&fired); n != stopped {
+		t.Errorf("fired %d times but should have stopped after %d", n, stopped)
+	}
+
+	p, _ = isoperiod.Parse("PT0.01S")
+	p.Schedule(time.Now(), func(time.Time) {
+		t.Error("fired but should have no repetitions")
+	})()
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestScheduleSigned(t *testing.T) {
+	// P1M-30D steps from January 31 to February 1, then back to January 30,
+	// so skipping the past occurrences would never end.
+	p, err := isoperiod.ParseWithMode("R/P1M-30D", isoperiod.ModeExtended)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := runtime.NumGoroutine()
+	start, _ := time.Parse(time.RFC3339, "2023-01-31T00:00:00Z")
+	var fired int32
+	stop := p.Schedule(start, func(time.Time) {
+		atomic.AddInt32(&fired, 1)
+	})
+	defer stop()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatal("schedule is still running but should have stopped")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Only start itself is called.
+	if n := atomic.LoadInt32(&fired); n != 1 {
+		t.Errorf("fired %d times but should be 1", n)
+	}
+}
+
+func TestScheduleJitter(t *testing.T) {
+	p, err := isoperiod.Parse("R5/PT0.1S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same seed results in the same delays.
+	expected := rand.New(rand.NewSource(42))
+	window := 50 * time.Millisecond
+
+	type call struct {
+		scheduled time.Time
+		fired     time.Time
+	}
+
+	start := time.Now().Add(20 * time.Millisecond)
+	calls := make(chan call, 10)
+	stop := p.Schedule(start, func(t time.Time) {
+		calls <- call{scheduled: t, fired: time.Now()}
+	}, isoperiod.WithJitter(0.5), isoperiod.WithRand(rand.New(rand.NewSource(42))))
+	defer stop()
+
+	for i, want := range p.Times(start) {
+		select {
+		case c := <-calls:
+			if !c.scheduled.Equal(want) {
+				t.Errorf("occurrence %d is %s but should be %s", i, c.scheduled, want)
+			}
+
+			delay := time.Duration(expected.Float64() * float64(window))
+			if earliest := want.Add(delay); c.fired.Before(earliest) {
+				t.Errorf("occurrence %d fired at %s, before %s", i, c.fired, earliest)
+			}
+
+			if latest := want.Add(window + 50*time.Millisecond); c.fired.After(latest) {
+				t.Errorf("occurrence %d fired at %s, after %s", i, c.fired, latest)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("fired %d times but should be 5", i)
+		}
+	}
+}
+
+func TestScheduleLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	p, err := isoperiod.Parse("R2/PT0.05S")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fired := make(chan time.Time, 2)
+	stop := p.Schedule(time.Now(), func(t time.Time) {
+		fired <- t
+	}, isoperiod.WithLocation(loc))
+	defer stop()
+
+	select {
+	case got := <-fired:
+		if got.Location() != loc {
+			t.Errorf("location is %s but should be %s", got.Location(), loc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("fired 0 times but should have fired once")
+	}
+}
+
+func TestScheduleLocationDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	p, err := isoperiod.Parse("R/P1D")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Start at the wall clock time of soon, on the most recent day with a different
+	// UTC offset, so the daily occurrences cross a daylight saving transition.
+	soon := time.Now().Add(300 * time.Millisecond).In(loc)
+	_, offset := soon.Zone()
+
+	var start time.Time
+	for days := 1; days <= 366; days++ {
+		d := soon.AddDate(0, 0, -days)
+		if _, o := d.Zone(); o != offset {
+			start = d
+			break
+		}
+	}
+
+	if start.IsZero() {
+		t.Skip("no daylight saving transition within a year")
+	}
+
+	// start is given in UTC, so only WithLocation makes the schedule follow the wall clock of loc.
+	fired := make(chan time.Time, 2)
+	stop := p.Schedule(start.UTC(), func(t time.Time) {
+		fired <- t
+	}, isoperiod.WithLocation(loc))
+	defer stop()
+
+	// start itself is called right away, the next occurrence is soon. Without the wall clock
+	// of loc, it would be an hour off and not fire within the test.
+	for i, want := range []time.Time{start, soon} {
+		select {
+		case got := <-fired:
+			if !got.Equal(want) {
+				t.Errorf("occurrence %d is %s but should be %s", i, got, want)
+			}
+
+			if got.Hour() != start.Hour() || got.Minute() != start.Minute() || got.Second() != start.Second() {
+				t.Errorf("occurrence %d is at %s but should be at the wall clock time %s", i, got.Format("15:04:05"), start.Format("15:04:05"))
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("fired %d times but should be 2", i)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package isoperiod
+
+// SetHour sets the hours.
+func (r *Period) SetHour(n int) {
+	r.Hour = n
+}
+
+// SetMinute sets the minutes.
+func (r *Period) SetMinute(n int) {
+	r.Minute = n
+}
+
+// SetSecond sets the seconds.
+func (r *Period) SetSecond(n int) {
+	r.Second = n
+}
+
+// SetNanosecond sets the nanoseconds.
+func (r *Period) SetNanosecond(n int) {
+	r.Nanosecond = n
+}
+
+// HMS returns the hours, minutes and seconds of the period.
+// The fraction of a second and the sign of the period are not applied.
+func (r *Period) HMS() (h, m, s int) {
+	return r.Hour, r.Minute, r.Second
+}
+
+// YMD returns the years, months and days of the period.
+// Weeks are not included in the days, and the sign of the period is not applied.
+func (r *Period) YMD() (y, m, d int) {
+	return r.Year, r.Month, r.Day
+}
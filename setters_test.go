@@ -0,0 +1,106 @@
+package isoperiod_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestSetters(t *testing.T) {
+	p := isoperiod.New(now, isoperiod.WithDays(1), isoperiod.WithHours(1))
+
+	p.SetHour(3)
+	if d, want := p.Duration(), 3*time.Hour; d != want {
+		t.Errorf("duration is %s but should be %s", d, want)
+	}
+
+	p.SetMinute(30)
+	p.SetSecond(15)
+	p.SetNanosecond(500)
+	if d, want := p.Duration(), 3*time.Hour+30*time.Minute+15*time.Second+500; d != want {
+		t.Errorf("duration is %s but should be %s", d, want)
+	}
+
+	if d, want := p.ApproxDuration(), isoperiod.ApproxDay+3*time.Hour+30*time.Minute+15*time.Second+500; d != want {
+		t.Errorf("approximate duration is %s but should be %s", d, want)
+	}
+
+	if s, want := p.String(), "P1DT3H30M15.0000005S"; s != want {
+		t.Errorf("period is %s but should be %s", s, want)
+	}
+}
+
+func TestFieldMutation(t *testing.T) {
+	p, err := isoperiod.Parse("R/PT1M")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Minute = 5
+
+	if next, want := p.Next(now), now.Add(5*time.Minute); !next.Equal(want) {
+		t.Errorf("next is %s but should be %s", next, want)
+	}
+
+	if d, want := p.Duration(), 5*time.Minute; d != want {
+		t.Errorf("duration is %s but should be %s", d, want)
+	}
+
+	if d, want := p.ApproxDuration(), 5*time.Minute; d != want {
+		t.Errorf("approximate duration is %s but should be %s", d, want)
+	}
+
+	q := isoperiod.New(now, isoperiod.WithHours(1))
+	q.Hour = 2
+	if !q.Equal(isoperiod.New(now, isoperiod.WithHours(2))) {
+		t.Errorf("period is %s but should be PT2H", q)
+	}
+}
+
+func TestHMSYMD(t *testing.T) {
+	testTable := []struct {
+		S   string
+		HMS [3]int
+		YMD [3]int
+	}{
+		{
+			S:   "R5/P1Y2M3DT4H5M6S",
+			HMS: [3]int{4, 5, 6},
+			YMD: [3]int{1, 2, 3},
+		},
+		{
+			S:   "P1DT12H",
+			HMS: [3]int{12, 0, 0},
+			YMD: [3]int{0, 0, 1},
+		},
+		{
+			S:   "-PT90M1.5S",
+			HMS: [3]int{0, 90, 1},
+			YMD: [3]int{0, 0, 0},
+		},
+		{
+			S:   "P2W",
+			HMS: [3]int{0, 0, 0},
+			YMD: [3]int{0, 0, 0},
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.Parse(testCase.S)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		h, m, s := p.HMS()
+		if hms := [3]int{h, m, s}; hms != testCase.HMS {
+			t.Errorf("%s: HMS is %v but should be %v", testCase.S, hms, testCase.HMS)
+		}
+
+		y, mo, d := p.YMD()
+		if ymd := [3]int{y, mo, d}; ymd != testCase.YMD {
+			t.Errorf("%s: YMD is %v but should be %v", testCase.S, ymd, testCase.YMD)
+		}
+	}
+}
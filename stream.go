@@ -0,0 +1,73 @@
+package isoperiod
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Decoder reads newline-delimited periods from an input stream.
+type Decoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{scanner: bufio.NewScanner(r)}
+}
+
+// Decode reads the next period from the input.
+//
+// Each line holds one period, surrounding whitespace is ignored.
+// Blank lines and comment lines starting with # are skipped.
+// At the end of the input, Decode returns io.EOF.
+func (d *Decoder) Decode() (*Period, error) {
+	for d.scanner.Scan() {
+		d.line++
+
+		s := strings.TrimSpace(d.scanner.Text())
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+
+		p, err := Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", d.line, err)
+		}
+
+		return p, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+// An Encoder writes periods as ISO 8601 lines to an output stream.
+// The output is buffered, call Flush when done.
+type Encoder struct {
+	w *bufio.Writer
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes p followed by a newline.
+func (e *Encoder) Encode(p *Period) error {
+	if _, err := e.w.WriteString(p.String()); err != nil {
+		return err
+	}
+
+	return e.w.WriteByte('\n')
+}
+
+// Flush writes any buffered periods to the underlying writer.
+func (e *Encoder) Flush() error {
+	return e.w.Flush()
+}
@@ -0,0 +1,93 @@
+package isoperiod_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestDecoder(t *testing.T) {
+	input := strings.Join([]string{
+		"# backup schedules",
+		"R5/PT30S",
+		"",
+		"  P1Y6M\t",
+		"   ",
+		"# weekly",
+		"R/P1W",
+	}, "\n")
+
+	dec := isoperiod.NewDecoder(strings.NewReader(input))
+
+	for _, want := range []string{"R5/PT30S", "P1Y6M", "R/P1W"} {
+		p, err := dec.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s := p.String(); s != want {
+			t.Errorf("period is %s but should be %s", s, want)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := dec.Decode(); err != io.EOF {
+			t.Errorf("error is %v but should be %q", err, io.EOF.Error())
+		}
+	}
+
+	dec = isoperiod.NewDecoder(strings.NewReader("P1D\n\nP1Mfoo\nP2D\n"))
+	if _, err := dec.Decode(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := dec.Decode()
+	if err := checkError(errors.New(`line 3: parsing "P1Mfoo" at offset 3: invalid period format`), err); err != nil {
+		t.Error(err)
+	}
+
+	if !errors.Is(err, isoperiod.ErrInvalidFormat) {
+		t.Errorf("error is %v but should be %q", err, isoperiod.ErrInvalidFormat.Error())
+	}
+
+	if p, err := dec.Decode(); err != nil || p.String() != "P2D" {
+		t.Errorf("period is %v (%v) but should be P2D", p, err)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	input := "R5/PT30S\nP1Y6M\nR/P1W\n-PT1.5S\nPT0S\n"
+
+	var buf strings.Builder
+	dec := isoperiod.NewDecoder(strings.NewReader(input))
+	enc := isoperiod.NewEncoder(&buf)
+
+	for {
+		p, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := enc.Encode(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("output is %q but should be buffered", buf.String())
+	}
+
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != input {
+		t.Errorf("output is %q but should be %q", buf.String(), input)
+	}
+}
@@ -0,0 +1,50 @@
+package isoperiod
+
+import (
+	"fmt"
+)
+
+// Validate checks the period for strict compliance.
+//
+// Negative components (and repetitions other than Endless) are always rejected.
+// Additionally hours must be less than 24, minutes and seconds less than 60,
+// and weeks can't be combined with years, months or days (ErrWeekWithOtherFields).
+//
+// Parse doesn't apply the range checks, lax parsing stays the default. Use ParseStrict
+// to parse and validate in one step.
+func (r *Period) Validate() error {
+	if r.Repetitions < Endless {
+		return fmt.Errorf("%w: repetitions can't be %d", ErrOutOfRange, r.Repetitions)
+	}
+
+	components := []struct {
+		name  string
+		value int
+		limit int
+	}{
+		{"year", r.Year, 0},
+		{"month", r.Month, 0},
+		{"week", r.Week, 0},
+		{"day", r.Day, 0},
+		{"hour", r.Hour, 24},
+		{"minute", r.Minute, 60},
+		{"second", r.Second, 60},
+		{"nanosecond", r.Nanosecond, 1000000000},
+	}
+
+	for _, c := range components {
+		if c.value < 0 {
+			return fmt.Errorf("%w: %s can't be negative", ErrOutOfRange, c.name)
+		}
+
+		if c.limit > 0 && c.value >= c.limit {
+			return fmt.Errorf("%w: %s must be less than %d", ErrOutOfRange, c.name, c.limit)
+		}
+	}
+
+	if r.Week != 0 && (r.Year != 0 || r.Month != 0 || r.Day != 0) {
+		return ErrWeekWithOtherFields
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package isoperiod_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestValidate(t *testing.T) {
+	testTable := []struct {
+		Period *isoperiod.Period
+		Err    error
+	}{
+		{
+			Period: isoperiod.New(now, isoperiod.WithHours(23), isoperiod.WithMinutes(59), isoperiod.WithSeconds(59)),
+			Err:    nil,
+		},
+		{
+			Period: isoperiod.New(now, isoperiod.WithRepetitions(-1), isoperiod.WithDays(400)),
+			Err:    nil,
+		},
+		{
+			Period: isoperiod.New(now, isoperiod.WithMinutes(99)),
+			Err:    errors.New("component out of range: minute must be less than 60"),
+		},
+		{
+			Period: isoperiod.New(now, isoperiod.WithSeconds(60)),
+			Err:    errors.New("component out of range: second must be less than 60"),
+		},
+		{
+			Period: isoperiod.New(now, isoperiod.WithHours(24)),
+			Err:    errors.New("component out of range: hour must be less than 24"),
+		},
+		{
+			Period: isoperiod.New(now, isoperiod.WithDays(-1)),
+			Err:    errors.New("component out of range: day can't be negative"),
+		},
+		{
+			Period: &isoperiod.Period{Repetitions: -2},
+			Err:    errors.New("component out of range: repetitions can't be -2"),
+		},
+		{
+			Period: isoperiod.New(now, isoperiod.WithWeeks(1), isoperiod.WithDays(2)),
+			Err:    isoperiod.ErrWeekWithOtherFields,
+		},
+		{
+			Period: isoperiod.New(now, isoperiod.WithWeeks(1), isoperiod.WithHours(2)),
+			Err:    nil,
+		},
+	}
+
+	for _, testCase := range testTable {
+		if err := checkError(testCase.Err, testCase.Period.Validate()); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	testTable := []struct {
+		S   string
+		Err error
+	}{
+		{
+			S:   "PT1H30M",
+			Err: nil,
+		},
+		{
+			S:   "PT90M",
+			Err: errors.New("component out of range: minute must be less than 60"),
+		},
+		{
+			S:   "P1Mfoo",
+			Err: errors.New("parsing \"P1Mfoo\" at offset 3: invalid period format"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		_, err := isoperiod.ParseStrict(testCase.S)
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if _, err := isoperiod.Parse("PT90M"); err != nil {
+		t.Errorf("lax parsing should accept PT90M, got %q", err.Error())
+	}
+}
+
+func TestParseWithMode(t *testing.T) {
+	testTable := []struct {
+		S      string
+		Lax    error
+		Strict error
+	}{
+		{
+			S:      "R5/PT1H30M",
+			Lax:    nil,
+			Strict: nil,
+		},
+		{
+			S:      "PT90M",
+			Lax:    nil,
+			Strict: errors.New("component out of range: minute must be less than 60"),
+		},
+		{
+			S:      "PT24H",
+			Lax:    nil,
+			Strict: errors.New("component out of range: hour must be less than 24"),
+		},
+		{
+			S:      "P1W2D",
+			Lax:    nil,
+			Strict: isoperiod.ErrWeekWithOtherFields,
+		},
+		{
+			S:      "P1Mfoo",
+			Lax:    errors.New("parsing \"P1Mfoo\" at offset 3: invalid period format"),
+			Strict: errors.New("parsing \"P1Mfoo\" at offset 3: invalid period format"),
+		},
+		{
+			S:      "P1H",
+			Lax:    errors.New("parsing \"P1H\" at offset 1: invalid period format"),
+			Strict: errors.New("parsing \"P1H\" at offset 1: invalid period format"),
+		},
+		{
+			S:      "PT",
+			Lax:    errors.New("parsing \"PT\" at offset 2: period has no components"),
+			Strict: errors.New("parsing \"PT\" at offset 2: period has no components"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		_, err := isoperiod.ParseWithMode(testCase.S, isoperiod.ModeLax)
+		if err := checkError(testCase.Lax, err); err != nil {
+			t.Errorf("%s (lax): %s", testCase.S, err)
+		}
+
+		_, err = isoperiod.ParseWithMode(testCase.S, isoperiod.ModeStrict)
+		if err := checkError(testCase.Strict, err); err != nil {
+			t.Errorf("%s (strict): %s", testCase.S, err)
+		}
+	}
+
+	_, err := isoperiod.ParseWithMode("P1D", isoperiod.ParseMode(42))
+	if err := checkError(errors.New("unknown parse mode 42"), err); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	testTable := []struct {
+		S    string
+		Want string
+		Err  error
+	}{
+		{
+			S:    "1Y6M",
+			Want: "P1Y6M",
+		},
+		{
+			S:    "R5/T30S",
+			Want: "R5/PT30S",
+		},
+		{
+			S:    "-1DT2H",
+			Want: "-P1DT2H",
+		},
+		{
+			S:    "P1Y6M",
+			Want: "P1Y6M",
+		},
+		{
+			S:   "",
+			Err: errors.New("parsing \"\" at offset 0: period has no components"),
+		},
+		{
+			S:   "1H",
+			Err: errors.New("parsing \"1H\" at offset 0: invalid period format"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.ParseWithMode(testCase.S, isoperiod.ModeLenient)
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Errorf("%q: %s", testCase.S, err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		if s := p.String(); s != testCase.Want {
+			t.Errorf("string is %s but should be %s", s, testCase.Want)
+		}
+	}
+
+	for _, mode := range []isoperiod.ParseMode{isoperiod.ModeLax, isoperiod.ModeStrict} {
+		if _, err := isoperiod.ParseWithMode("1Y6M", mode); !errors.Is(err, isoperiod.ErrInvalidFormat) {
+			t.Errorf("mode %d: error is %v but should be %q", mode, err, isoperiod.ErrInvalidFormat.Error())
+		}
+	}
+}
+
+func TestParseExtended(t *testing.T) {
+	testTable := []struct {
+		S    string
+		T    string
+		Want string
+		Err  error
+	}{
+		{
+			S:    "P1Y-2M",
+			T:    "2023-01-15T00:00:00Z",
+			Want: "2023-11-15T00:00:00Z",
+		},
+		{
+			S:    "R/P1DT-1H",
+			T:    "2023-01-15T00:00:00Z",
+			Want: "2023-01-15T23:00:00Z",
+		},
+		{
+			S:    "PT1M-1.5S",
+			T:    "2023-01-15T00:00:00Z",
+			Want: "2023-01-15T00:00:58.5Z",
+		},
+		{
+			S:    "-P1Y-2M",
+			T:    "2023-01-15T00:00:00Z",
+			Want: "2022-03-15T00:00:00Z",
+		},
+		{
+			S:    "P2W",
+			T:    "2023-01-15T00:00:00Z",
+			Want: "2023-01-29T00:00:00Z",
+		},
+		{
+			S:   "P1Y--2M",
+			Err: errors.New("parsing \"P1Y--2M\" at offset 3: invalid period format"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.ParseWithMode(testCase.S, isoperiod.ModeExtended)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		start, _ := time.Parse(time.RFC3339, testCase.T)
+		want, _ := time.Parse(time.RFC3339, testCase.Want)
+
+		if got := p.Add(start); !got.Equal(want) {
+			t.Errorf("%s + %s is %s but should be %s", testCase.T, testCase.S, got, want)
+		}
+
+		if got := p.Sub(want); !got.Equal(start) {
+			t.Errorf("%s - %s is %s but should be %s", testCase.Want, testCase.S, got, start)
+		}
+
+		if s := p.String(); s != testCase.S {
+			t.Errorf("string is %s but should be %s", s, testCase.S)
+		}
+	}
+
+	p, err := isoperiod.ParseWithMode("P1Y-2M", isoperiod.ModeExtended)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Year != 1 || p.Month != -2 {
+		t.Errorf("components are %d years and %d months but should be 1 and -2", p.Year, p.Month)
+	}
+
+	for _, mode := range []isoperiod.ParseMode{isoperiod.ModeLax, isoperiod.ModeStrict} {
+		if _, err := isoperiod.ParseWithMode("P1Y-2M", mode); !errors.Is(err, isoperiod.ErrInvalidFormat) {
+			t.Errorf("mode %d: error is %v but should be %q", mode, err, isoperiod.ErrInvalidFormat.Error())
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package isoperiod
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseXSDDuration converts an xsd:duration string to a period.
+//
+// The xsd lexical space is stricter than ISO 8601: besides an optional leading sign
+// and at least one component, it forbids repetitions, weeks, the comma as decimal sign
+// and lowercase designators.
+func ParseXSDDuration(s string) (*Period, error) {
+	forbidden := []struct {
+		c   rune
+		err string
+	}{
+		{'/', "xsd:duration doesn't allow repetitions"},
+		{'W', "xsd:duration doesn't allow weeks"},
+		{',', "xsd:duration only allows a dot as decimal sign"},
+	}
+
+	for _, f := range forbidden {
+		if i := strings.IndexRune(s, f.c); i >= 0 {
+			return nil, &ParseError{Input: s, Offset: i, Err: fmt.Errorf("%w: %s", ErrInvalidFormat, f.err)}
+		}
+	}
+
+	if i := strings.IndexFunc(s, unicode.IsLower); i >= 0 {
+		return nil, &ParseError{Input: s, Offset: i, Err: fmt.Errorf("%w: xsd:duration designators are uppercase", ErrInvalidFormat)}
+	}
+
+	return Parse(s)
+}
+
+// XSDDuration returns the canonical xsd:duration representation of the period.
+//
+// Unlike String, the canonical form normalizes the components: months are carried
+// into years, and seconds into minutes, hours and days, since xsd:duration counts
+// a day as 24 hours. Weeks are expanded to days and repetitions are dropped.
+// P1DT25H results in P2DT1H, P2W in P14D and a period without length in PT0S.
+//
+// xsd:duration only has a sign for the whole duration. Signed components (see ModeExtended)
+// are folded into it, so P-1M results in -P1M and P1DT-1H in PT23H. Periods whose months
+// and seconds end up with different signs, like P1M-1D, have no xsd:duration and are rejected.
+func (r *Period) XSDDuration() (string, error) {
+	sign := int64(r.sign())
+	months := sign * (int64(r.Year)*12 + int64(r.Month))
+	seconds := sign * ((int64(r.Week)*7+int64(r.Day))*86400 + int64(r.Hour)*3600 + int64(r.Minute)*60 + int64(r.Second))
+	nanoseconds := sign * int64(r.Nanosecond)
+
+	seconds += nanoseconds / 1000000000
+	nanoseconds %= 1000000000
+	switch {
+	case seconds > 0 && nanoseconds < 0:
+		seconds--
+		nanoseconds += 1000000000
+	case seconds < 0 && nanoseconds > 0:
+		seconds++
+		nanoseconds -= 1000000000
+	}
+
+	negative := months < 0 || seconds < 0 || nanoseconds < 0
+	if negative && (months > 0 || seconds > 0 || nanoseconds > 0) {
+		return "", fmt.Errorf("xsd:duration can't express %s, its components have mixed signs", r)
+	}
+
+	if negative {
+		months, seconds, nanoseconds = -months, -seconds, -nanoseconds
+	}
+
+	zero := months == 0 && seconds == 0 && nanoseconds == 0
+
+	var b strings.Builder
+	if negative {
+		b.WriteString("-")
+	}
+	b.WriteString("P")
+
+	if y := months / 12; y != 0 {
+		fmt.Fprintf(&b, "%dY", y)
+	}
+	if m := months % 12; m != 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if d := seconds / 86400; d != 0 {
+		fmt.Fprintf(&b, "%dD", d)
+	}
+
+	h, m, s := seconds%86400/3600, seconds%3600/60, seconds%60
+	if h != 0 || m != 0 || s != 0 || nanoseconds != 0 || zero {
+		b.WriteString("T")
+	}
+	if h != 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m != 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	if s != 0 || nanoseconds != 0 || zero {
+		fmt.Fprintf(&b, "%d", s)
+		if nanoseconds != 0 {
+			fmt.Fprintf(&b, ".%s", strings.TrimRight(fmt.Sprintf("%09d", nanoseconds), "0"))
+		}
+		b.WriteString("S")
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,186 @@
+package isoperiod_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/christopher-kleine/isoperiod"
+)
+
+func TestParseXSDDuration(t *testing.T) {
+	testTable := []struct {
+		S   string
+		Err error
+	}{
+		{
+			S:   "P1Y2M3DT10H30M",
+			Err: nil,
+		},
+		{
+			S:   "-P120D",
+			Err: nil,
+		},
+		{
+			S:   "PT1.5S",
+			Err: nil,
+		},
+		{
+			S:   "R5/PT30S",
+			Err: errors.New("parsing \"R5/PT30S\" at offset 2: invalid period format: xsd:duration doesn't allow repetitions"),
+		},
+		{
+			S:   "P2W",
+			Err: errors.New("parsing \"P2W\" at offset 2: invalid period format: xsd:duration doesn't allow weeks"),
+		},
+		{
+			S:   "PT1,5S",
+			Err: errors.New("parsing \"PT1,5S\" at offset 3: invalid period format: xsd:duration only allows a dot as decimal sign"),
+		},
+		{
+			S:   "P1dT2H",
+			Err: errors.New("parsing \"P1dT2H\" at offset 2: invalid period format: xsd:duration designators are uppercase"),
+		},
+		{
+			S:   "P",
+			Err: errors.New("parsing \"P\" at offset 1: period has no components"),
+		},
+		{
+			S:   "P1DT",
+			Err: errors.New("parsing \"P1DT\" at offset 4: invalid period format: time designator without time components"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.ParseXSDDuration(testCase.S)
+
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if testCase.Err == nil && p.String() != testCase.S {
+			t.Errorf("period is %s but should be %s", p, testCase.S)
+		}
+	}
+}
+
+func TestXSDDuration(t *testing.T) {
+	testTable := []struct {
+		S   string
+		XSD string
+		Err error
+	}{
+		{
+			S:   "P1Y2M3DT4H5M6S",
+			XSD: "P1Y2M3DT4H5M6S",
+		},
+		{
+			S:   "R5/PT30S",
+			XSD: "PT30S",
+		},
+		{
+			S:   "P14M",
+			XSD: "P1Y2M",
+		},
+		{
+			S:   "P1DT25H",
+			XSD: "P2DT1H",
+		},
+		{
+			S:   "PT90M",
+			XSD: "PT1H30M",
+		},
+		{
+			S:   "P2W",
+			XSD: "P14D",
+		},
+		{
+			S:   "PT0.5S",
+			XSD: "PT0.5S",
+		},
+		{
+			S:   "P12M",
+			XSD: "P1Y",
+		},
+		{
+			S:   "-P1DT0.25S",
+			XSD: "-P1DT0.25S",
+		},
+		{
+			S:   "PT0S",
+			XSD: "PT0S",
+		},
+		{
+			S:   "-PT0S",
+			XSD: "PT0S",
+		},
+		{
+			S:   "P-1M",
+			XSD: "-P1M",
+		},
+		{
+			S:   "PT-30M",
+			XSD: "-PT30M",
+		},
+		{
+			S:   "PT-0.5S",
+			XSD: "-PT0.5S",
+		},
+		{
+			S:   "-PT-1.5S",
+			XSD: "PT1.5S",
+		},
+		{
+			S:   "PT1M-0.5S",
+			XSD: "PT59.5S",
+		},
+		{
+			S:   "P1DT-1H",
+			XSD: "PT23H",
+		},
+		{
+			S:   "P1M-1D",
+			Err: errors.New("xsd:duration can't express P1M-1D, its components have mixed signs"),
+		},
+		{
+			S:   "-P1Y-13M",
+			XSD: "P1M",
+		},
+		{
+			S:   "-P1MT-1S",
+			Err: errors.New("xsd:duration can't express -P1MT-1S, its components have mixed signs"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		p, err := isoperiod.ParseWithMode(testCase.S, isoperiod.ModeExtended)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		xsd, err := p.XSDDuration()
+		if err := checkError(testCase.Err, err); err != nil {
+			t.Errorf("%s: %s", testCase.S, err)
+			continue
+		}
+
+		if testCase.Err != nil {
+			continue
+		}
+
+		if xsd != testCase.XSD {
+			t.Errorf("%s: xsd:duration is %s but should be %s", testCase.S, xsd, testCase.XSD)
+		}
+
+		if _, err := isoperiod.ParseXSDDuration(xsd); err != nil {
+			t.Errorf("%s: %s", testCase.S, err)
+		}
+	}
+
+	// A directly set negative nanosecond is carried into the seconds.
+	p := &isoperiod.Period{Second: 2, Nanosecond: -500000000}
+	if xsd, err := p.XSDDuration(); err != nil || xsd != "PT1.5S" {
+		t.Errorf("xsd:duration is %s (%v) but should be PT1.5S", xsd, err)
+	}
+}